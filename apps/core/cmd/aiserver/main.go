@@ -0,0 +1,88 @@
+// Command aiserver runs a local implementation of the AgentService gRPC
+// surface that cmd/worker's aiClient otherwise expects the separate Python
+// AI service to provide. It's meant for local development and integration
+// tests where that service isn't available, not as a production
+// replacement for it.
+package main
+
+import (
+	"context"
+	"flag"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"iterateswarm-core/internal/grpc"
+	"iterateswarm-core/internal/logging"
+	"iterateswarm-core/internal/otel"
+)
+
+func main() {
+	grpcAddr := flag.String("grpc-addr", ":50051", "AgentService gRPC listen address")
+	gatewayAddr := flag.String("gateway-addr", ":8090", "REST/JSON gateway listen address (empty disables it)")
+	authToken := flag.String("auth-token", os.Getenv("AI_SERVER_AUTH_TOKEN"), "Bearer token required on every RPC (disabled when empty)")
+	flag.Parse()
+
+	logger := logging.NewLogger("aiserver")
+	logger.Info("starting AgentService gRPC server...")
+
+	shutdownTracing, err := otel.InitTracerProvider(context.Background(), "iterateswarm-aiserver")
+	if err != nil {
+		logger.Error("failed to init tracing", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Warn("failed to flush traces", "error", err.Error())
+		}
+	}()
+
+	agent := grpc.NewAgentServer(grpc.NaiveAnalyzer{}, logger)
+	srv := grpc.NewGRPCServer(grpc.ServerConfig{
+		AuthToken: *authToken,
+		RateLimit: grpc.DefaultRateLimitConfig(),
+	}, agent, logger)
+
+	lis, err := net.Listen("tcp", *grpcAddr)
+	if err != nil {
+		logger.Error("failed to listen", err, "addr", *grpcAddr)
+		os.Exit(1)
+	}
+
+	go func() {
+		logger.Info("AgentService gRPC server listening", "addr", *grpcAddr)
+		if err := srv.Serve(lis); err != nil {
+			logger.Error("grpc server error", err)
+		}
+	}()
+
+	var gatewaySrv *http.Server
+	if *gatewayAddr != "" {
+		mux, err := grpc.NewGatewayMux(context.Background(), *grpcAddr)
+		if err != nil {
+			logger.Error("failed to start REST/JSON gateway", err)
+		} else {
+			gatewaySrv = &http.Server{Addr: *gatewayAddr, Handler: mux}
+			go func() {
+				logger.Info("AgentService REST/JSON gateway listening", "addr", *gatewayAddr)
+				if err := gatewaySrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					logger.Error("gateway server error", err)
+				}
+			}()
+		}
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("shutting down AgentService gRPC server...")
+	srv.GracefulStop()
+	if gatewaySrv != nil {
+		if err := gatewaySrv.Shutdown(context.Background()); err != nil {
+			logger.Warn("failed to shut down gateway server", "error", err.Error())
+		}
+	}
+}
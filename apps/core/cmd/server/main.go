@@ -1,18 +1,29 @@
 package main
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"flag"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
+	"github.com/gofiber/contrib/otelfiber/v2"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"go.temporal.io/sdk/interceptor"
 
 	"iterateswarm-core/internal/api"
+	"iterateswarm-core/internal/bridge/wiring"
+	"iterateswarm-core/internal/debug"
+	"iterateswarm-core/internal/otel"
 	"iterateswarm-core/internal/redpanda"
 	"iterateswarm-core/internal/temporal"
 )
@@ -25,20 +36,94 @@ func main() {
 	port := flag.String("port", "3000", "HTTP server port")
 	topic := flag.String("topic", "feedback-events", "Kafka topic")
 
+	kafkaTLSEnabled := flag.Bool("kafka-tls-enabled", os.Getenv("KAFKA_TLS_ENABLED") == "true", "Enable TLS for the Kafka connection")
+	kafkaTLSCAFile := flag.String("kafka-tls-ca-file", os.Getenv("KAFKA_TLS_CA_FILE"), "PEM CA bundle to verify the Kafka broker certificate")
+	kafkaSASLMechanism := flag.String("kafka-sasl-mechanism", os.Getenv("KAFKA_SASL_MECHANISM"), "Kafka SASL mechanism: PLAIN, SCRAM-SHA-256, SCRAM-SHA-512 or OAUTHBEARER")
+	kafkaSASLUsername := flag.String("kafka-sasl-username", os.Getenv("KAFKA_SASL_USERNAME"), "Kafka SASL username (PLAIN/SCRAM)")
+	kafkaSASLPassword := flag.String("kafka-sasl-password", os.Getenv("KAFKA_SASL_PASSWORD"), "Kafka SASL password (PLAIN/SCRAM)")
+	kafkaOAuthClientID := flag.String("kafka-oauth-client-id", os.Getenv("KAFKA_OAUTH_CLIENT_ID"), "Kafka OAUTHBEARER client ID")
+	kafkaOAuthClientSecret := flag.String("kafka-oauth-client-secret", os.Getenv("KAFKA_OAUTH_CLIENT_SECRET"), "Kafka OAUTHBEARER client secret")
+	kafkaOAuthTokenURL := flag.String("kafka-oauth-token-url", os.Getenv("KAFKA_OAUTH_TOKEN_URL"), "Kafka OAUTHBEARER token endpoint")
+	kafkaOAuthScopes := flag.String("kafka-oauth-scopes", os.Getenv("KAFKA_OAUTH_SCOPES"), "Comma-separated Kafka OAUTHBEARER scopes")
+
+	traceBackend := flag.String("trace-backend", os.Getenv("TRACE_BACKEND"), "Distributed tracing backend for LiteDebug's trace viewer: jaeger, zipkin, tempo or otlp")
+	traceBackendURL := flag.String("trace-backend-url", os.Getenv("TRACE_BACKEND_URL"), "Base URL of the trace backend (falls back to its conventional default when empty)")
+
+	schemaRegistryURL := flag.String("schema-registry-url", os.Getenv("SCHEMA_REGISTRY_URL"), "Base URL of a Confluent-compatible schema registry for LiteDebug's Kafka browser to decode Avro/Protobuf/JSON Schema payloads (disabled when empty)")
+
+	discordPublicKey := flag.String("discord-public-key", os.Getenv("DISCORD_PUBLIC_KEY"), "Hex-encoded Ed25519 public key Discord signs interaction webhooks with (required for /webhooks/interaction)")
+	slackSigningSecret := flag.String("slack-signing-secret", os.Getenv("SLACK_SIGNING_SECRET"), "Slack app signing secret (required for /webhooks/slack/events and /webhooks/slack/interaction)")
+	debugAdminToken := flag.String("debug-admin-token", os.Getenv("DEBUG_ADMIN_TOKEN"), "Bearer token required to reach destructive /api/debug/kafka admin routes (disabled, i.e. unauthenticated, when empty)")
+
 	flag.Parse()
 
 	log.Println("Starting IterateSwarm Core Server...")
 
+	// rootCtx is cancelled on SIGINT/SIGTERM so the Redpanda consumer and
+	// any ConsumeWorkers loop built on it shut down deterministically
+	// before Close runs, instead of racing live goroutines.
+	rootCtx, cancelRoot := context.WithCancel(context.Background())
+	defer cancelRoot()
+
+	shutdownTracing, err := otel.InitTracerProvider(context.Background(), "iterateswarm-core")
+	if err != nil {
+		log.Fatalf("Failed to init tracing: %v", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("Failed to flush traces: %v", err)
+		}
+	}()
+
+	redpandaCfg := redpanda.DefaultClientConfig([]string{*redpandaBrokers}, *topic)
+	if *kafkaTLSEnabled {
+		tlsConfig := &tls.Config{}
+		if *kafkaTLSCAFile != "" {
+			caCert, err := os.ReadFile(*kafkaTLSCAFile)
+			if err != nil {
+				log.Fatalf("Failed to read Kafka TLS CA bundle %s: %v", *kafkaTLSCAFile, err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caCert) {
+				log.Fatalf("Failed to parse Kafka TLS CA bundle %s", *kafkaTLSCAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		redpandaCfg.TLS = tlsConfig
+	}
+	if *kafkaSASLMechanism != "" {
+		redpandaCfg.SASLMechanism = redpanda.SASLMechanism(strings.ToUpper(*kafkaSASLMechanism))
+		redpandaCfg.Username = *kafkaSASLUsername
+		redpandaCfg.Password = *kafkaSASLPassword
+		if redpandaCfg.SASLMechanism == redpanda.SASLOAuthBearer {
+			var scopes []string
+			if *kafkaOAuthScopes != "" {
+				scopes = strings.Split(*kafkaOAuthScopes, ",")
+			}
+			redpandaCfg.OAuth = redpanda.OAuthConfig{
+				ClientID:     *kafkaOAuthClientID,
+				ClientSecret: *kafkaOAuthClientSecret,
+				TokenURL:     *kafkaOAuthTokenURL,
+				Scopes:       scopes,
+			}
+		}
+	}
+
 	// Initialize Redpanda client
-	redpandaClient, err := redpanda.NewClient([]string{*redpandaBrokers}, *topic)
+	redpandaClient, err := redpanda.NewClientWithConfig(rootCtx, redpandaCfg)
 	if err != nil {
 		log.Fatalf("Failed to connect to Redpanda: %v", err)
 	}
-	defer redpandaClient.Close()
+	defer redpandaClient.Close(context.Background())
 	log.Println("Connected to Redpanda")
 
+	temporalInterceptor, err := otel.NewTemporalInterceptor()
+	if err != nil {
+		log.Fatalf("Failed to build Temporal tracing interceptor: %v", err)
+	}
+
 	// Initialize Temporal client
-	temporalClient, err := temporal.NewClient(*temporalAddr, *namespace)
+	temporalClient, err := temporal.NewClientWithInterceptors(*temporalAddr, *namespace, []interceptor.ClientInterceptor{temporalInterceptor.(interceptor.ClientInterceptor)})
 	if err != nil {
 		log.Fatalf("Failed to connect to Temporal: %v", err)
 	}
@@ -53,6 +138,7 @@ func main() {
 
 	// Middleware
 	app.Use(recover.New())
+	app.Use(otelfiber.Middleware())
 	app.Use(logger.New(logger.Config{
 		Format: "[${time}] ${status} - ${method} ${path} (${latency})\n",
 	}))
@@ -65,9 +151,44 @@ func main() {
 	app.Get("/health", handler.HandleHealth)
 	app.Get("/health/details", handler.HandleDetailedHealth)
 	app.Post("/webhooks/discord", handler.HandleDiscordWebhook)
-	app.Post("/webhooks/interaction", handler.HandleInteraction)
+	if *discordPublicKey != "" {
+		pubKeyBytes, err := hex.DecodeString(*discordPublicKey)
+		if err != nil || len(pubKeyBytes) != ed25519.PublicKeySize {
+			log.Fatalf("Invalid discord-public-key: must be a hex-encoded Ed25519 public key")
+		}
+		app.Post("/webhooks/interaction", api.DiscordVerify(ed25519.PublicKey(pubKeyBytes)), handler.HandleInteraction)
+	} else {
+		log.Println("discord-public-key not set; /webhooks/interaction is disabled")
+	}
+	if *slackSigningSecret != "" {
+		slackVerify := api.SlackVerify(*slackSigningSecret)
+		app.Post("/webhooks/slack/events", slackVerify, handler.HandleSlackEvent)
+		app.Post("/webhooks/slack/interaction", slackVerify, handler.HandleSlackInteraction)
+	} else {
+		log.Println("slack-signing-secret not set; /webhooks/slack/* is disabled")
+	}
 	app.Get("/test/kafka", handler.HandleKafkaTest)
 
+	traceSource, err := debug.NewTraceSource(*traceBackend, *traceBackendURL)
+	if err != nil {
+		log.Fatalf("Failed to configure trace backend: %v", err)
+	}
+	debugHandler := debug.NewHandlerWithTraceSource(redpandaClient, temporalClient, traceSource)
+	if *schemaRegistryURL != "" {
+		debugHandler.SetSchemaRegistry(debug.NewSchemaRegistryDecoder(*schemaRegistryURL, 0))
+	}
+	bridges, err := wiring.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure issue-tracker bridges: %v", err)
+	}
+	debugHandler.SetBridges(bridges)
+	debugHandler.SetAdminToken(*debugAdminToken)
+	if *debugAdminToken == "" {
+		log.Println("debug-admin-token not set; /api/debug/kafka admin routes are unauthenticated")
+	}
+	debugHandler.SetJobManager(redpanda.NewJobManager([]string{*redpandaBrokers}, temporalClient.Client))
+	debugHandler.RegisterRoutes(app)
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -75,6 +196,7 @@ func main() {
 	go func() {
 		<-quit
 		log.Println("Shutting down server...")
+		cancelRoot()
 		if err := app.Shutdown(); err != nil {
 			log.Printf("Error during shutdown: %v", err)
 		}
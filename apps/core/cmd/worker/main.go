@@ -2,61 +2,183 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
-	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/segmentio/kafka-go"
+	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/worker"
 
+	"iterateswarm-core/internal/bridge/wiring"
+	"iterateswarm-core/internal/discord"
 	"iterateswarm-core/internal/grpc"
+	"iterateswarm-core/internal/integrations"
+	discordgateway "iterateswarm-core/internal/integrations/discord/gateway"
+	"iterateswarm-core/internal/logging"
+	"iterateswarm-core/internal/otel"
+	"iterateswarm-core/internal/redpanda"
 	"iterateswarm-core/internal/temporal"
 	"iterateswarm-core/internal/workflow"
 )
 
+// feedbackEvent is the JSON shape internal/api.HandleDiscordWebhook and
+// HandleSlackEvent publish to the feedback-events topic.
+type feedbackEvent struct {
+	FeedbackID string `json:"feedback_id"`
+	Text       string `json:"text"`
+	Source     string `json:"source"`
+	UserID     string `json:"user_id"`
+	ChannelID  string `json:"channel_id,omitempty"`
+}
+
 func main() {
+	logger := logging.NewLogger("worker")
+
 	// Command line flags
 	temporalAddr := flag.String("temporal", "localhost:7233", "Temporal address")
 	namespace := flag.String("namespace", "default", "Temporal namespace")
 	aiGRPCAddr := flag.String("ai-grpc", "localhost:50051", "Python AI service gRPC address")
 	taskQueue := flag.String("queue", "feedback-queue", "Task queue name")
+	redpandaBrokers := flag.String("redpanda", "localhost:19092", "Redpanda brokers")
+	feedbackTopic := flag.String("topic", "feedback-events", "Kafka topic feedback events are ingested from")
+	feedbackConsumers := flag.Int("feedback-consumers", 4, "Number of feedback-events consumer goroutines")
 
 	flag.Parse()
 
-	log.Println("Starting IterateSwarm Worker...")
+	logger.Info("starting IterateSwarm worker")
+
+	// rootCtx is cancelled on SIGINT/SIGTERM so the feedback-events
+	// ConsumeWorkers loop shuts down deterministically before Close runs,
+	// instead of racing a live goroutine.
+	rootCtx, cancelRoot := context.WithCancel(context.Background())
+	defer cancelRoot()
+
+	shutdownTracing, err := otel.InitTracerProvider(rootCtx, "iterateswarm-worker")
+	if err != nil {
+		logger.Error("failed to init tracing", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Warn("failed to flush traces", "error", err.Error())
+		}
+	}()
+
+	temporalInterceptor, err := otel.NewTemporalInterceptor()
+	if err != nil {
+		logger.Error("failed to build Temporal tracing interceptor", err)
+		os.Exit(1)
+	}
 
 	// Initialize Temporal client
-	temporalClient, err := temporal.NewClient(*temporalAddr, *namespace)
+	temporalClient, err := temporal.NewClientWithInterceptors(*temporalAddr, *namespace, []interceptor.ClientInterceptor{temporalInterceptor.(interceptor.ClientInterceptor)})
 	if err != nil {
-		log.Fatalf("Failed to connect to Temporal: %v", err)
+		logger.Error("failed to connect to Temporal", err)
+		os.Exit(1)
 	}
 	defer temporalClient.Close()
-	log.Println("Connected to Temporal")
+	logger.Info("connected to Temporal")
+
+	// Initialize Redpanda client for the feedback-events consumer
+	redpandaClient, err := redpanda.NewClient(rootCtx, []string{*redpandaBrokers}, *feedbackTopic)
+	if err != nil {
+		logger.Error("failed to connect to Redpanda", err)
+		os.Exit(1)
+	}
+	defer redpandaClient.Close(context.Background())
+	logger.Info("connected to Redpanda")
 
 	// Initialize gRPC client for AI service
 	aiClient, err := grpc.NewClientWithoutBlock(*aiGRPCAddr)
 	if err != nil {
-		log.Printf("Warning: Failed to connect to AI gRPC server: %v", err)
-		log.Println("Worker will start, but AI calls will fail until AI service is available")
+		logger.Warn("failed to connect to AI gRPC server, AI calls will fail until it is available", "error", err.Error())
 	} else {
 		defer aiClient.Close()
-		log.Println("Connected to AI gRPC service")
+		logger.Info("connected to AI gRPC service")
 	}
 
 	// Create Temporal worker
-	w := worker.New(temporalClient.Client, *taskQueue, worker.Options{})
+	w := worker.New(temporalClient.Client, *taskQueue, worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{temporalInterceptor.(interceptor.WorkerInterceptor)},
+	})
 
 	// Register workflow and activities
 	w.RegisterWorkflow(workflow.FeedbackWorkflow)
 
-	activities := workflow.NewActivities(aiClient)
+	// Batch concurrent AnalyzeFeedback activity executions into shared
+	// AnalyzeFeedbackStream frames so Discord/Kafka bursts cost one
+	// streamed RPC instead of one unary RPC per feedback item.
+	batcher := grpc.NewStreamBatcher(aiClient, grpc.DefaultBatchConfig())
+	activities := workflow.NewActivitiesWithBatcher(aiClient, batcher)
+
+	bridges, err := wiring.FromEnv()
+	if err != nil {
+		logger.Error("failed to configure issue-tracker bridges", err)
+		os.Exit(1)
+	}
+	if bridges != nil {
+		activities.SetBridges(bridges)
+		logger.Info("CreateGitHubIssue will route through the bridge registry", "trackers", bridges.List())
+	}
+
+	if rawURLs := os.Getenv("NOTIFY_URLS"); rawURLs != "" {
+		notifier, err := integrations.NewMultiSender(strings.Split(rawURLs, ","))
+		if err != nil {
+			logger.Error("failed to configure NOTIFY_URLS", err)
+			os.Exit(1)
+		}
+		activities.SetNotifier(notifier)
+		logger.Info("CreateGitHubIssue will fan out notifications via NOTIFY_URLS")
+	}
+
 	w.RegisterActivity(activities.AnalyzeFeedback)
 	w.RegisterActivity(activities.SendDiscordApproval)
+	w.RegisterActivity(activities.SendSlackApproval)
 	w.RegisterActivity(activities.CreateGitHubIssue)
 
-	log.Printf("Worker listening on task queue: %s", *taskQueue)
+	// Start the Discord gateway so Approve/Reject button clicks can be
+	// correlated back to the workflow that posted them.
+	if discordToken := os.Getenv("DISCORD_BOT_TOKEN"); discordToken != "" {
+		var allowedRoles []string
+		if roles := os.Getenv("DISCORD_APPROVER_ROLE_IDS"); roles != "" {
+			allowedRoles = strings.Split(roles, ",")
+		}
+
+		gateway, err := discord.NewGateway(discordToken, temporalClient, allowedRoles)
+		if err != nil {
+			logger.Warn("failed to create Discord gateway", "error", err.Error())
+		} else if err := gateway.Start(); err != nil {
+			logger.Warn("failed to start Discord gateway", "error", err.Error())
+		} else {
+			defer gateway.Close()
+			logger.Info("Discord gateway connected, listening for approval interactions")
+		}
+	} else {
+		logger.Info("DISCORD_BOT_TOKEN not set, skipping Discord gateway")
+	}
+
+	// Start the low-level Discord Gateway WebSocket client so MESSAGE_CREATE/
+	// INTERACTION_CREATE/presence events flow into Redpanda for LiteDebug's
+	// Kafka browser and any feedback-events-style consumer to see, alongside
+	// (not instead of) the discordgo-based approval gateway above.
+	if discordToken := os.Getenv("DISCORD_BOT_TOKEN"); discordToken != "" {
+		ws := discordgateway.NewGateway(discordgateway.Config{
+			Token: discordToken,
+			Intents: discordgateway.IntentGuilds | discordgateway.IntentGuildMessages |
+				discordgateway.IntentGuildMessageReactions | discordgateway.IntentMessageContent,
+		})
+		go ws.Run(rootCtx)
+		go discordgateway.PublishToRedpanda(rootCtx, ws.Bus, redpandaClient, logger)
+		defer ws.Close()
+		logger.Info("Discord Gateway WebSocket client started, publishing events to redpanda")
+	}
+
+	logger.Info("worker listening", "task_queue", *taskQueue)
 
 	// Start worker in goroutine
 	errCh := make(chan error, 1)
@@ -67,20 +189,52 @@ func main() {
 		}
 	}()
 
+	// Consume feedback-events and start a FeedbackWorkflow per event, so
+	// Discord/Slack ingestion (internal/api.HandleDiscordWebhook/
+	// HandleSlackEvent) actually results in a running workflow instead of
+	// sitting unread in Kafka. ConsumeWorkers blocks until rootCtx is
+	// cancelled, so it runs in its own goroutine alongside the Temporal worker.
+	consumerDone := make(chan struct{})
+	go func() {
+		defer close(consumerDone)
+		err := redpandaClient.ConsumeWorkers(rootCtx, *feedbackTopic, *feedbackConsumers, func(ctx context.Context, msg kafka.Message) error {
+			var event feedbackEvent
+			if err := json.Unmarshal(msg.Value, &event); err != nil {
+				logger.Error("failed to decode feedback event", err)
+				return nil
+			}
+
+			workflowID := "feedback-" + event.FeedbackID
+			_, err := temporalClient.StartWorkflow(ctx, workflowID, *taskQueue, "FeedbackWorkflow", workflow.FeedbackInput{
+				Text:      event.Text,
+				Source:    event.Source,
+				UserID:    event.UserID,
+				ChannelID: event.ChannelID,
+			})
+			return err
+		})
+		if err != nil && rootCtx.Err() == nil {
+			logger.Error("feedback-events consumer stopped", err)
+		}
+	}()
+
 	// Wait for shutdown signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	select {
 	case <-quit:
-		log.Println("Shutting down worker...")
+		logger.Info("shutting down worker")
+		cancelRoot()
 	case err := <-errCh:
-		log.Printf("Worker error: %v", err)
+		logger.Error("worker error", err)
+		cancelRoot()
 	}
+	<-consumerDone
 
 	// Give activities time to complete
 	_, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	log.Println("Worker stopped")
+	logger.Info("worker stopped")
 }
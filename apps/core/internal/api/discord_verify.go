@@ -0,0 +1,37 @@
+package api
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DiscordVerify returns Fiber middleware that authenticates Discord
+// interaction webhooks: Discord signs "X-Signature-Timestamp"+body with
+// the application's private key, and every request must verify against
+// publicKey before being parsed, or Discord's own endpoint validation
+// (and every real interaction) will be rejected.
+//
+// See https://discord.com/developers/docs/interactions/overview#setting-up-an-endpoint.
+func DiscordVerify(publicKey ed25519.PublicKey) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		signature := c.Get("X-Signature-Ed25519")
+		timestamp := c.Get("X-Signature-Timestamp")
+		if signature == "" || timestamp == "" {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+
+		sig, err := hex.DecodeString(signature)
+		if err != nil || len(sig) != ed25519.SignatureSize {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+
+		message := append([]byte(timestamp), c.Body()...)
+		if !ed25519.Verify(publicKey, message, sig) {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newDiscordVerifyTestApp(t *testing.T, pub ed25519.PublicKey) *fiber.App {
+	t.Helper()
+	app := fiber.New()
+	app.Post("/interactions", DiscordVerify(pub), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func signDiscordRequest(priv ed25519.PrivateKey, timestamp string, body []byte) string {
+	message := append([]byte(timestamp), body...)
+	return hex.EncodeToString(ed25519.Sign(priv, message))
+}
+
+func TestDiscordVerifyAcceptsValidSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	app := newDiscordVerifyTestApp(t, pub)
+
+	body := []byte(`{"type":1}`)
+	timestamp := "1700000000"
+	sig := signDiscordRequest(priv, timestamp, body)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/interactions", bytes.NewReader(body))
+	req.Header.Set("X-Signature-Ed25519", sig)
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+}
+
+func TestDiscordVerifyRejectsTamperedBody(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	app := newDiscordVerifyTestApp(t, pub)
+
+	timestamp := "1700000000"
+	sig := signDiscordRequest(priv, timestamp, []byte(`{"type":1}`))
+
+	// The signature was computed over a different body than the one sent.
+	req := httptest.NewRequest(fiber.MethodPost, "/interactions", bytes.NewReader([]byte(`{"type":2}`)))
+	req.Header.Set("X-Signature-Ed25519", sig)
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
+
+func TestDiscordVerifyRejectsWrongKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	app := newDiscordVerifyTestApp(t, pub)
+
+	body := []byte(`{"type":1}`)
+	timestamp := "1700000000"
+	sig := signDiscordRequest(otherPriv, timestamp, body)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/interactions", bytes.NewReader(body))
+	req.Header.Set("X-Signature-Ed25519", sig)
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
+
+func TestDiscordVerifyRejectsMissingHeaders(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	app := newDiscordVerifyTestApp(t, pub)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/interactions", bytes.NewReader([]byte(`{"type":1}`)))
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
+
+func TestDiscordVerifyRejectsMalformedSignature(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	app := newDiscordVerifyTestApp(t, pub)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/interactions", bytes.NewReader([]byte(`{"type":1}`)))
+	req.Header.Set("X-Signature-Ed25519", "not-hex-and-wrong-length")
+	req.Header.Set("X-Signature-Timestamp", "1700000000")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
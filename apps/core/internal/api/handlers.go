@@ -3,12 +3,13 @@ package api
 import (
 	"encoding/json"
 	"runtime"
-	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
+	"iterateswarm-core/internal/api/interactions"
+	"iterateswarm-core/internal/discord"
 	"iterateswarm-core/internal/logging"
 	"iterateswarm-core/internal/redpanda"
 	"iterateswarm-core/internal/temporal"
@@ -51,18 +52,93 @@ type InteractionUser struct {
 
 // Handler handles API requests.
 type Handler struct {
-	redpandaClient  *redpanda.Client
-	temporalClient  *temporal.Client
-	logger          *logging.Logger
+	redpandaClient    *redpanda.Client
+	temporalClient    *temporal.Client
+	logger            *logging.Logger
+	interactions      *interactions.Router
+	slackInteractions *interactions.Router
 }
 
-// NewHandler creates a new Handler.
+// NewHandler creates a new Handler. Its interaction routers come wired
+// with the "approve"/"reject" prefixes used by
+// workflow.Activities.SendDiscordApproval/SendSlackApproval's buttons,
+// each signaling the workflow named by the remainder of the custom_id/
+// action_id. The two platforms don't agree on a delimiter (Discord's
+// discordgo-built custom_id is "approve_<id>", Slack's action_id is
+// "approve:<id>"), so each Router is built with its own.
 func NewHandler(redpandaClient *redpanda.Client, temporalClient *temporal.Client) *Handler {
-	return &Handler{
-		redpandaClient: redpandaClient,
-		temporalClient: temporalClient,
-		logger:         logging.NewLogger("api"),
+	h := &Handler{
+		redpandaClient:    redpandaClient,
+		temporalClient:    temporalClient,
+		logger:            logging.NewLogger("api"),
+		interactions:      interactions.NewRouter("_"),
+		slackInteractions: interactions.NewRouter(":"),
 	}
+
+	h.interactions.OnButton("approve", h.discordSignalWorkflowAction)
+	h.interactions.OnButton("reject", h.discordSignalWorkflowAction)
+
+	h.slackInteractions.OnButton("approve", h.slackSignalWorkflowAction)
+	h.slackInteractions.OnButton("reject", h.slackSignalWorkflowAction)
+
+	return h
+}
+
+// signalWorkflowAction signals the workflow named by req.Arg with a
+// discord.ApprovalSignal, the exact struct and signal name
+// FeedbackWorkflow (workflow.go) waits on — the same contract
+// discord.Gateway's own signalAndAck uses for Gateway-delivered
+// interactions. It does not write a response: discordSignalWorkflowAction
+// and slackSignalWorkflowAction each ack in the shape their own platform
+// expects.
+func (h *Handler) signalWorkflowAction(c *fiber.Ctx, req interactions.Request) error {
+	h.logger.Info("interaction received",
+		"custom_id", req.CustomID,
+		"user_id", req.UserID,
+		"username", req.Username,
+		"channel_id", req.ChannelID,
+	)
+
+	signal := discord.ApprovalSignal{
+		Approved: req.Action == "approve",
+		UserID:   req.UserID,
+	}
+
+	if err := h.temporalClient.SignalWorkflow(req.Arg, discord.ApprovalSignalName, signal); err != nil {
+		h.logger.Error("failed to signal workflow", err, "workflow_id", req.Arg, "action", req.Action)
+		return err
+	}
+
+	return nil
+}
+
+// discordSignalWorkflowAction acks with Discord's interaction response
+// envelope (type 4: CHANNEL_MESSAGE_WITH_SOURCE).
+func (h *Handler) discordSignalWorkflowAction(c *fiber.Ctx, req interactions.Request) error {
+	if err := h.signalWorkflowAction(c, req); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(map[string]string{
+			"error": "Failed to process action",
+		})
+	}
+	return c.JSON(map[string]interface{}{
+		"type": 4,
+		"data": map[string]string{
+			"content": "Action received!",
+		},
+	})
+}
+
+// slackSignalWorkflowAction acks a block_actions interaction the way
+// Slack expects: an empty 200 within 3 seconds, with no response body
+// (Slack ignores the body unless it sets response_action, which this
+// button-only flow never needs).
+func (h *Handler) slackSignalWorkflowAction(c *fiber.Ctx, req interactions.Request) error {
+	if err := h.signalWorkflowAction(c, req); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(map[string]string{
+			"error": "Failed to process action",
+		})
+	}
+	return c.SendStatus(fiber.StatusOK)
 }
 
 // HandleDiscordWebhook processes Discord webhook events.
@@ -127,7 +203,10 @@ func (h *Handler) HandleDiscordWebhook(c *fiber.Ctx) error {
 	})
 }
 
-// HandleInteraction processes Discord interactions (button clicks).
+// HandleInteraction processes Discord interactions (button clicks). It
+// must run behind DiscordVerify so only Discord-signed requests reach it;
+// dispatch by custom_id prefix (and the built-in PING response) lives in
+// h.interactions.
 func (h *Handler) HandleInteraction(c *fiber.Ctx) error {
 	var req InteractionRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -137,42 +216,178 @@ func (h *Handler) HandleInteraction(c *fiber.Ctx) error {
 		})
 	}
 
-	// Handle Discord's ping interaction
-	if req.Type == 1 {
-		return c.JSON(map[string]interface{}{
-			"type": 1,
+	if err := h.interactions.Handle(c, interactions.Request{
+		Type:      req.Type,
+		CustomID:  req.Data.CustomID,
+		ChannelID: req.ChannelID,
+		UserID:    req.User.ID,
+		Username:  req.User.Username,
+	}); err != nil {
+		h.logger.Error("failed to handle interaction", err, "custom_id", req.Data.CustomID)
+		return c.Status(fiber.StatusInternalServerError).JSON(map[string]string{
+			"error": "Failed to process action",
 		})
 	}
 
-	h.logger.Info("interaction received",
-		"custom_id", req.Data.CustomID,
-		"user_id", req.User.ID,
-		"username", req.User.Username,
-		"channel_id", req.ChannelID,
-	)
+	return nil
+}
+
+// SlackEventEnvelope wraps Slack's Events API payload, covering both the
+// one-time url_verification handshake and event_callback notifications.
+type SlackEventEnvelope struct {
+	Type      string          `json:"type"`
+	Challenge string          `json:"challenge,omitempty"`
+	Event     SlackInnerEvent `json:"event,omitempty"`
+}
+
+// SlackInnerEvent is the subset of Slack's nested "event" fields this
+// handler forwards.
+type SlackInnerEvent struct {
+	Type    string `json:"type"`
+	User    string `json:"user,omitempty"`
+	Text    string `json:"text,omitempty"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// HandleSlackEvent processes Slack's Events API callbacks. It must run
+// behind SlackVerify. url_verification challenges are echoed back
+// immediately; event_callback notifications for message, app_mention and
+// reaction_added are forwarded to feedback-events the same way
+// HandleDiscordWebhook forwards Discord feedback.
+func (h *Handler) HandleSlackEvent(c *fiber.Ctx) error {
+	var envelope SlackEventEnvelope
+	if err := c.BodyParser(&envelope); err != nil {
+		h.logger.Error("failed to parse slack event", err, "error", err.Error())
+		return c.Status(fiber.StatusBadRequest).JSON(map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	if envelope.Type == "url_verification" {
+		return c.JSON(map[string]string{"challenge": envelope.Challenge})
+	}
+
+	if envelope.Type != "event_callback" {
+		return c.SendStatus(fiber.StatusOK)
+	}
 
-	// Signal the workflow (parse custom_id for action and workflowID)
-	parts := strings.Split(req.Data.CustomID, ":")
-	action := parts[0]
-	workflowID := ""
-	if len(parts) > 1 {
-		workflowID = parts[1]
+	switch envelope.Event.Type {
+	case "message", "app_mention", "reaction_added":
+	default:
+		return c.SendStatus(fiber.StatusOK)
 	}
 
-	err := h.temporalClient.SignalWorkflow(c.Context(), workflowID, "user-action", action)
+	feedbackID := uuid.New().String()
+	event := map[string]interface{}{
+		"feedback_id": feedbackID,
+		"text":        envelope.Event.Text,
+		"source":      "slack",
+		"user_id":     envelope.Event.User,
+		"timestamp":   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	data, err := json.Marshal(event)
 	if err != nil {
-		h.logger.Error("failed to signal workflow", err, "workflow_id", workflowID, "action", action)
+		h.logger.Error("failed to marshal slack event", err, "feedback_id", feedbackID)
+		return c.Status(fiber.StatusInternalServerError).JSON(map[string]string{
+			"error": "Failed to process event",
+		})
+	}
+
+	if err := h.redpandaClient.Publish("feedback-events", data); err != nil {
+		h.logger.Error("failed to publish slack event to redpanda", err, "feedback_id", feedbackID, "topic", "feedback-events")
+		return c.Status(fiber.StatusInternalServerError).JSON(map[string]string{
+			"error": "Failed to queue event",
+		})
+	}
+
+	h.logger.Info("slack event ingested",
+		"feedback_id", feedbackID,
+		"event_type", envelope.Event.Type,
+		"user_id", envelope.Event.User,
+	)
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// SlackInteractionPayload is the JSON decoded from interactivity requests'
+// form-encoded "payload" field.
+type SlackInteractionPayload struct {
+	Type    string               `json:"type"`
+	User    SlackInteractionUser `json:"user"`
+	Actions []SlackAction        `json:"actions,omitempty"`
+	View    *SlackView           `json:"view,omitempty"`
+}
+
+// SlackInteractionUser identifies who triggered the interaction.
+type SlackInteractionUser struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// SlackAction is one Block Kit element click reported in a block_actions payload.
+type SlackAction struct {
+	ActionID string `json:"action_id"`
+	Value    string `json:"value"`
+}
+
+// SlackView is the subset of a modal view's fields needed to route a
+// view_submission.
+type SlackView struct {
+	CallbackID string `json:"callback_id"`
+}
+
+// HandleSlackInteraction processes Slack's interactivity requests (Block
+// Kit button clicks and modal submissions). It must run behind
+// SlackVerify. block_actions dispatches its first action by action_id
+// prefix and view_submission dispatches by the view's callback_id,
+// through h.slackInteractions — the same action_id → workflow mapping
+// Discord's custom_id router uses.
+func (h *Handler) HandleSlackInteraction(c *fiber.Ctx) error {
+	raw := c.FormValue("payload")
+	if raw == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(map[string]string{
+			"error": "Missing payload",
+		})
+	}
+
+	var payload SlackInteractionPayload
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		h.logger.Error("failed to parse slack interaction payload", err, "error", err.Error())
+		return c.Status(fiber.StatusBadRequest).JSON(map[string]string{
+			"error": "Invalid payload",
+		})
+	}
+
+	var customID string
+	switch payload.Type {
+	case "block_actions":
+		if len(payload.Actions) == 0 {
+			return c.SendStatus(fiber.StatusOK)
+		}
+		customID = payload.Actions[0].ActionID
+	case "view_submission":
+		if payload.View == nil {
+			return c.SendStatus(fiber.StatusOK)
+		}
+		customID = payload.View.CallbackID
+	default:
+		return c.SendStatus(fiber.StatusOK)
+	}
+
+	if err := h.slackInteractions.Handle(c, interactions.Request{
+		Type:     2, // Slack has no PING type; anything other than 1 skips it.
+		CustomID: customID,
+		UserID:   payload.User.ID,
+		Username: payload.User.Username,
+	}); err != nil {
+		h.logger.Error("failed to handle slack interaction", err, "custom_id", customID)
 		return c.Status(fiber.StatusInternalServerError).JSON(map[string]string{
 			"error": "Failed to process action",
 		})
 	}
 
-	return c.JSON(map[string]interface{}{
-		"type": 4,
-		"data": map[string]string{
-			"content": "Action received!",
-		},
-	})
+	return nil
 }
 
 // HandleHealth returns a simple health status.
@@ -0,0 +1,76 @@
+// Package interactions dispatches Discord and Slack interaction webhooks
+// (https://discord.com/developers/docs/interactions/receiving-and-responding)
+// by type, and button/select-menu interactions further by their
+// custom_id/action_id's prefix.
+package interactions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Request is the subset of an interaction payload handlers need. Action
+// and Arg are populated by Router.Handle from CustomID's prefix/suffix
+// around the router's delimiter, so handlers never need to re-split
+// CustomID (and re-guess which delimiter it uses) themselves.
+type Request struct {
+	Type      int
+	CustomID  string
+	Action    string
+	Arg       string
+	ChannelID string
+	UserID    string
+	Username  string
+}
+
+// Handler handles one custom_id prefix's button/select interactions.
+type Handler func(c *fiber.Ctx, req Request) error
+
+// Router dispatches interactions registered by custom_id prefix (the part
+// of custom_id before its first occurrence of delimiter). Discord and
+// Slack don't agree on a separator — SendDiscordApproval's buttons use
+// "approve_<id>" (discordgo's CustomID convention) while SendSlackApproval's
+// use "approve:<id>" — so each platform's Router is built with its own
+// delimiter via NewRouter. PINGs (type 1) are answered automatically,
+// without needing a registered handler, so Discord's endpoint verification
+// during dashboard setup succeeds as soon as the route exists.
+type Router struct {
+	delimiter string
+	handlers  map[string]Handler
+}
+
+// NewRouter creates an empty Router that splits custom_ids on delimiter.
+func NewRouter(delimiter string) *Router {
+	return &Router{delimiter: delimiter, handlers: make(map[string]Handler)}
+}
+
+// OnButton registers handler for interactions whose custom_id prefix
+// equals prefix, e.g. OnButton("approve", ...) matches "approve<delimiter>wf-123".
+func (r *Router) OnButton(prefix string, handler Handler) {
+	r.handlers[prefix] = handler
+}
+
+// Handle dispatches req to the handler registered for its custom_id
+// prefix, answering PINGs inline first.
+func (r *Router) Handle(c *fiber.Ctx, req Request) error {
+	if req.Type == 1 {
+		return c.JSON(fiber.Map{"type": 1})
+	}
+
+	prefix := req.CustomID
+	arg := ""
+	if idx := strings.Index(req.CustomID, r.delimiter); idx >= 0 {
+		prefix = req.CustomID[:idx]
+		arg = req.CustomID[idx+len(r.delimiter):]
+	}
+	req.Action = prefix
+	req.Arg = arg
+
+	handler, ok := r.handlers[prefix]
+	if !ok {
+		return fmt.Errorf("no interaction handler registered for custom_id prefix %q", prefix)
+	}
+	return handler(c, req)
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newSlackVerifyTestApp(t *testing.T, signingSecret string) *fiber.App {
+	t.Helper()
+	app := fiber.New()
+	app.Post("/events", SlackVerify(signingSecret), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func signSlackRequest(signingSecret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSlackVerifyAcceptsValidSignature(t *testing.T) {
+	const signingSecret = "test-signing-secret"
+	app := newSlackVerifyTestApp(t, signingSecret)
+
+	body := []byte(`{"type":"event_callback"}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	sig := signSlackRequest(signingSecret, timestamp, body)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/events", bytes.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", sig)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+}
+
+func TestSlackVerifyRejectsTamperedBody(t *testing.T) {
+	const signingSecret = "test-signing-secret"
+	app := newSlackVerifyTestApp(t, signingSecret)
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	sig := signSlackRequest(signingSecret, timestamp, []byte(`{"type":"event_callback"}`))
+
+	// The signature was computed over a different body than the one sent.
+	req := httptest.NewRequest(fiber.MethodPost, "/events", bytes.NewReader([]byte(`{"type":"other"}`)))
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", sig)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
+
+func TestSlackVerifyRejectsWrongSigningSecret(t *testing.T) {
+	app := newSlackVerifyTestApp(t, "real-secret")
+
+	body := []byte(`{"type":"event_callback"}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	sig := signSlackRequest("wrong-secret", timestamp, body)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/events", bytes.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", sig)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
+
+func TestSlackVerifyRejectsStaleTimestamp(t *testing.T) {
+	const signingSecret = "test-signing-secret"
+	app := newSlackVerifyTestApp(t, signingSecret)
+
+	body := []byte(`{"type":"event_callback"}`)
+	timestamp := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	sig := signSlackRequest(signingSecret, timestamp, body)
+
+	req := httptest.NewRequest(fiber.MethodPost, "/events", bytes.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", sig)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
+
+func TestSlackVerifyRejectsMissingHeaders(t *testing.T) {
+	app := newSlackVerifyTestApp(t, "test-signing-secret")
+
+	req := httptest.NewRequest(fiber.MethodPost, "/events", bytes.NewReader([]byte(`{"type":"event_callback"}`)))
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,133 @@
+// Package bridge defines a tracker-agnostic interface for filing issues
+// against an external issue tracker (GitHub, GitLab, Jira, Launchpad, ...)
+// and a registry that routes a feedback item to the right one.
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// IssueSpec describes the issue to be filed, independent of the target tracker.
+type IssueSpec struct {
+	Title     string
+	Body      string
+	Labels    []string
+	Assignee  string
+	RepoOwner string
+	RepoName  string
+}
+
+// IssueRef identifies the issue that was created in the target tracker.
+type IssueRef struct {
+	Tracker string
+	ID      string
+	URL     string
+}
+
+// Bridge creates issues in a single external tracker.
+type Bridge interface {
+	// Name returns the tracker identifier, e.g. "github", "gitlab", "jira", "launchpad".
+	Name() string
+	// CreateIssue files a new issue and returns a reference to it.
+	CreateIssue(ctx context.Context, spec IssueSpec) (IssueRef, error)
+}
+
+// Route selects a bridge for a feedback item based on its labels.
+type Route struct {
+	// Label is matched against IssueSpec.Labels; empty Label is the catch-all.
+	Label string
+	// Bridge is the tracker name to route to, as registered with Registry.Register.
+	Bridge string
+}
+
+// Registry holds the configured bridges and the routing rules used to pick
+// one for a given issue.
+type Registry struct {
+	mu      sync.RWMutex
+	bridges map[string]Bridge
+	routes  []Route
+}
+
+// NewRegistry creates an empty bridge registry.
+func NewRegistry() *Registry {
+	return &Registry{bridges: make(map[string]Bridge)}
+}
+
+// Register adds a bridge under its Name().
+func (r *Registry) Register(b Bridge) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bridges[b.Name()] = b
+}
+
+// AddRoute appends a label -> bridge routing rule. Rules are evaluated in
+// the order they were added; the first match wins.
+func (r *Registry) AddRoute(route Route) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes = append(r.routes, route)
+}
+
+// Get returns the bridge registered under name, if any.
+func (r *Registry) Get(name string) (Bridge, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	b, ok := r.bridges[name]
+	return b, ok
+}
+
+// List returns the names of all registered bridges.
+func (r *Registry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.bridges))
+	for name := range r.bridges {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Route picks the bridge for spec by matching its labels against the
+// configured routes, falling back to the catch-all route (Label == "").
+func (r *Registry) Route(spec IssueSpec) (Bridge, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var fallback *Route
+	for i, route := range r.routes {
+		if route.Label == "" {
+			if fallback == nil {
+				fallback = &r.routes[i]
+			}
+			continue
+		}
+		for _, label := range spec.Labels {
+			if label == route.Label {
+				if b, ok := r.bridges[route.Bridge]; ok {
+					return b, nil
+				}
+				return nil, fmt.Errorf("bridge: route for label %q points at unregistered bridge %q", label, route.Bridge)
+			}
+		}
+	}
+
+	if fallback != nil {
+		if b, ok := r.bridges[fallback.Bridge]; ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("bridge: fallback route points at unregistered bridge %q", fallback.Bridge)
+	}
+
+	return nil, fmt.Errorf("bridge: no route matched labels %v and no fallback route configured", spec.Labels)
+}
+
+// CreateIssue routes spec to the appropriate bridge and creates the issue.
+func (r *Registry) CreateIssue(ctx context.Context, spec IssueSpec) (IssueRef, error) {
+	b, err := r.Route(spec)
+	if err != nil {
+		return IssueRef{}, err
+	}
+	return b.CreateIssue(ctx, spec)
+}
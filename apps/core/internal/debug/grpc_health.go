@@ -0,0 +1,22 @@
+package debug
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"iterateswarm-core/internal/grpc"
+)
+
+// SetAIClient attaches the AI gRPC client used by GetGRPCHealth. Nil is a
+// valid value: the route then reports the service as not configured.
+func (h *Handler) SetAIClient(aiClient *grpc.Client) {
+	h.aiClient = aiClient
+}
+
+// GetGRPCHealth returns the last observed health state of the Python AI
+// gRPC service's channel.
+func (h *Handler) GetGRPCHealth(c *fiber.Ctx) error {
+	if h.aiClient == nil {
+		return c.JSON(h.newResponse(true, grpc.HealthState{Serving: false, LastError: "AI gRPC client not configured"}, nil))
+	}
+	return c.JSON(h.newResponse(true, h.aiClient.Health(), nil))
+}
@@ -1,12 +1,21 @@
 package debug
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
 
+	"iterateswarm-core/internal/bridge"
+	"iterateswarm-core/internal/grpc"
 	"iterateswarm-core/internal/logging"
 	"iterateswarm-core/internal/redpanda"
 	"iterateswarm-core/internal/temporal"
@@ -28,16 +37,61 @@ type Handler struct {
 	redpandaClient  *redpanda.Client
 	temporalClient *temporal.Client
 	logger         *logging.Logger
-	jaegerURL      string
+	traces         TraceSource
+	bridges        *bridge.Registry
+	jobManager     *redpanda.JobManager
+	aiClient       *grpc.Client
+	schemaDecoder  *SchemaRegistryDecoder
+	adminToken     string
+
+	tailersMu sync.Mutex
+	tailers   map[string]*EventTailer
 }
 
-// NewHandler creates a new debug Handler.
+// SetAdminToken sets the bearer token required to reach the destructive
+// Kafka admin routes (CreateTopic/DeleteTopic/AlterTopicConfigs/
+// AlterPartitionReassignments). An empty token disables auth entirely, so
+// local development doesn't need one configured — the same convention
+// grpc's authUnaryServerInterceptor uses.
+func (h *Handler) SetAdminToken(token string) {
+	h.adminToken = token
+}
+
+// requireAdminToken rejects requests whose "Authorization: Bearer <token>"
+// header doesn't match h.adminToken. It guards routes that can delete
+// topics or reassign partitions outright, unlike the rest of the debug
+// surface, which is read-only or otherwise non-destructive.
+func (h *Handler) requireAdminToken(c *fiber.Ctx) error {
+	if h.adminToken == "" {
+		return c.Next()
+	}
+	if c.Get("Authorization") != "Bearer "+h.adminToken {
+		return c.Status(fiber.StatusUnauthorized).JSON(h.newResponse(false, nil, fmt.Errorf("invalid or missing bearer token")))
+	}
+	return c.Next()
+}
+
+// NewHandler creates a new debug Handler backed by a Jaeger trace source at
+// jaegerURL. Use NewHandlerWithTraceSource for Zipkin, Tempo or OTLP.
 func NewHandler(redpandaClient *redpanda.Client, temporalClient *temporal.Client, jaegerURL string) *Handler {
 	return &Handler{
 		redpandaClient:  redpandaClient,
 		temporalClient: temporalClient,
 		logger:         logging.NewLogger("debug"),
-		jaegerURL:      jaegerURL,
+		traces:         newJaegerSource(jaegerURL),
+		tailers:        make(map[string]*EventTailer),
+	}
+}
+
+// NewHandlerWithTraceSource creates a debug Handler whose trace viewer
+// queries an arbitrary TraceSource instead of always assuming Jaeger.
+func NewHandlerWithTraceSource(redpandaClient *redpanda.Client, temporalClient *temporal.Client, traces TraceSource) *Handler {
+	return &Handler{
+		redpandaClient:  redpandaClient,
+		temporalClient: temporalClient,
+		logger:         logging.NewLogger("debug"),
+		traces:         traces,
+		tailers:        make(map[string]*EventTailer),
 	}
 }
 
@@ -70,16 +124,46 @@ func (h *Handler) RegisterRoutes(app *fiber.App) {
 	debug.Get("/kafka/topics", h.ListKafkaTopics)
 	debug.Get("/kafka/topics/:name/messages", h.GetKafkaTopicMessages)
 	debug.Post("/kafka/test-message", h.PublishTestMessage)
+	debug.Get("/kafka/jobs", h.ListKafkaJobs)
+	debug.Post("/kafka/jobs", h.RegisterKafkaJob)
+	debug.Post("/kafka/jobs/:id/stop", h.StopKafkaJob)
+
+	// Kafka Admin. The mutating routes are destructive (they can delete
+	// topics or reassign partitions outright) so, unlike the rest of this
+	// read-only/non-destructive inspector, they sit behind requireAdminToken.
+	debug.Get("/kafka/cluster", h.DescribeCluster)
+	debug.Get("/kafka/topics/:name/describe", h.DescribeTopic)
+	debug.Post("/kafka/topics", h.requireAdminToken, h.CreateTopic)
+	debug.Delete("/kafka/topics/:name", h.requireAdminToken, h.DeleteTopic)
+	debug.Post("/kafka/topics/:name/configs", h.requireAdminToken, h.AlterTopicConfigs)
+	debug.Post("/kafka/partitions/reassign", h.requireAdminToken, h.AlterPartitionReassignments)
+	debug.Get("/kafka/partitions/reassign", h.ListPartitionReassignments)
+	debug.Get("/kafka/groups/:id", h.ConsumerGroupDescribe)
 
 	// Temporal Workflow Inspector
 	debug.Get("/workflows", h.ListWorkflows)
 	debug.Get("/workflows/:id", h.GetWorkflowDetails)
+	debug.Get("/workflows/:id/events", h.GetWorkflowEvents)
 
 	// Trace Viewer
 	debug.Get("/traces/:id", h.GetTraceDetails)
+	debug.Get("/traces/:id/analysis", h.GetTraceAnalysis)
 
 	// Event Trace
 	debug.Get("/events", h.ListRecentEvents)
+	debug.Get("/events/tail", h.TailEvents)
+	debug.Post("/events/tail/:session/ack", h.AckTailedEvent)
+
+	// Schema Registry
+	debug.Get("/schemas/subjects", h.ListSubjects)
+	debug.Get("/schemas/subjects/:subject/versions/:version", h.GetSchema)
+	debug.Post("/schemas/subjects/:subject/versions/:version/compatibility", h.CheckCompatibility)
+
+	// Bridge Inspector
+	debug.Get("/bridges", h.ListBridges)
+
+	// gRPC channel health
+	debug.Get("/grpc/health", h.GetGRPCHealth)
 }
 
 // ListKafkaTopics returns a list of all Kafka topics with metadata.
@@ -103,7 +187,7 @@ func (h *Handler) GetKafkaTopicMessages(c *fiber.Ctx) error {
 	offset := c.QueryInt("offset", 0)
 	limit := c.QueryInt("limit", 100)
 
-	messages, err := GetKafkaTopicMessages(ctx, h.redpandaClient, topicName, offset, limit)
+	messages, err := GetKafkaTopicMessages(ctx, h.redpandaClient, h.schemaDecoder, topicName, offset, limit)
 	if err != nil {
 		h.logger.Error("failed to get Kafka topic messages", err, "topic", topicName)
 		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
@@ -139,21 +223,174 @@ func (h *Handler) PublishTestMessage(c *fiber.Ctx) error {
 	}, nil))
 }
 
-// ListWorkflows returns a list of workflows with filtering.
+// DescribeCluster returns the cluster's controller, broker list and ACLs.
+func (h *Handler) DescribeCluster(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	desc, err := DescribeCluster(ctx, h.redpandaClient)
+	if err != nil {
+		h.logger.Error("failed to describe cluster", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, desc, nil))
+}
+
+// DescribeTopic returns configs and per-partition placement for a topic.
+func (h *Handler) DescribeTopic(c *fiber.Ctx) error {
+	ctx := c.Context()
+	topicName := c.Params("name")
+
+	descriptions, err := DescribeTopics(ctx, h.redpandaClient, []string{topicName})
+	if err != nil {
+		h.logger.Error("failed to describe topic", err, "topic", topicName)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+	if len(descriptions) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(h.newResponse(false, nil, fmt.Errorf("topic %s not found", topicName)))
+	}
+
+	return c.JSON(h.newResponse(true, descriptions[0], nil))
+}
+
+// CreateTopic creates a topic with the requested partition count and
+// replication factor.
+func (h *Handler) CreateTopic(c *fiber.Ctx) error {
+	var req struct {
+		Topic             string `json:"topic"`
+		Partitions        int    `json:"partitions"`
+		ReplicationFactor int    `json:"replication_factor"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, err))
+	}
+
+	ctx := c.Context()
+	if err := CreateTopic(ctx, h.redpandaClient, req.Topic, req.Partitions, req.ReplicationFactor); err != nil {
+		h.logger.Error("failed to create topic", err, "topic", req.Topic)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, map[string]string{"topic": req.Topic}, nil))
+}
+
+// DeleteTopic deletes a topic.
+func (h *Handler) DeleteTopic(c *fiber.Ctx) error {
+	ctx := c.Context()
+	topicName := c.Params("name")
+
+	if err := DeleteTopic(ctx, h.redpandaClient, topicName); err != nil {
+		h.logger.Error("failed to delete topic", err, "topic", topicName)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, map[string]string{"topic": topicName}, nil))
+}
+
+// AlterTopicConfigs sets a topic's dynamic configs.
+func (h *Handler) AlterTopicConfigs(c *fiber.Ctx) error {
+	topicName := c.Params("name")
+
+	var configs map[string]string
+	if err := c.BodyParser(&configs); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, err))
+	}
+
+	ctx := c.Context()
+	if err := AlterConfigs(ctx, h.redpandaClient, topicName, configs); err != nil {
+		h.logger.Error("failed to alter topic configs", err, "topic", topicName)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, map[string]string{"topic": topicName}, nil))
+}
+
+// AlterPartitionReassignments moves partitions onto the requested brokers.
+func (h *Handler) AlterPartitionReassignments(c *fiber.Ctx) error {
+	var req struct {
+		Assignments []PartitionAssignment `json:"assignments"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, err))
+	}
+
+	ctx := c.Context()
+	if err := AlterPartitionReassignments(ctx, h.redpandaClient, req.Assignments); err != nil {
+		h.logger.Error("failed to alter partition reassignments", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, map[string]int{"assignments": len(req.Assignments)}, nil))
+}
+
+// ListPartitionReassignments returns in-progress partition reassignments,
+// optionally filtered by the comma-separated "topics" query param.
+func (h *Handler) ListPartitionReassignments(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	var topics []string
+	if q := c.Query("topics"); q != "" {
+		topics = strings.Split(q, ",")
+	}
+
+	statuses, err := ListPartitionReassignments(ctx, h.redpandaClient, topics)
+	if err != nil {
+		h.logger.Error("failed to list partition reassignments", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, statuses, nil))
+}
+
+// ConsumerGroupDescribe returns a consumer group's member assignments and
+// per-partition offset lag.
+func (h *Handler) ConsumerGroupDescribe(c *fiber.Ctx) error {
+	ctx := c.Context()
+	groupID := c.Params("id")
+
+	desc, err := ConsumerGroupDescribe(ctx, h.redpandaClient, groupID)
+	if err != nil {
+		h.logger.Error("failed to describe consumer group", err, "group_id", groupID)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, desc, nil))
+}
+
+// ListWorkflows returns a page of workflows, filtered by status/type/start
+// time (the started_after/started_before query params, RFC3339) and
+// paginated via the page_token/next_page_token cursor.
 func (h *Handler) ListWorkflows(c *fiber.Ctx) error {
 	ctx := c.Context()
 
-	status := c.Query("status")
-	workflowType := c.Query("type")
+	query := WorkflowQuery{
+		Status:       c.Query("status"),
+		WorkflowType: c.Query("type"),
+	}
+	if v := c.Query("started_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, fmt.Errorf("invalid started_after: %w", err)))
+		}
+		query.StartedAfter = t
+	}
+	if v := c.Query("started_before"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, fmt.Errorf("invalid started_before: %w", err)))
+		}
+		query.StartedBefore = t
+	}
 	limit := c.QueryInt("limit", 50)
+	pageToken := c.Query("page_token")
 
-	workflows, err := ListWorkflows(ctx, h.temporalClient, status, workflowType, limit)
+	page, err := ListWorkflows(ctx, h.temporalClient, query, limit, pageToken)
 	if err != nil {
 		h.logger.Error("failed to list workflows", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
 	}
 
-	return c.JSON(h.newResponse(true, workflows, nil))
+	return c.JSON(h.newResponse(true, page, nil))
 }
 
 // GetWorkflowDetails returns details of a specific workflow.
@@ -170,12 +407,32 @@ func (h *Handler) GetWorkflowDetails(c *fiber.Ctx) error {
 	return c.JSON(h.newResponse(true, details, nil))
 }
 
-// GetTraceDetails returns trace details from Jaeger.
+// GetWorkflowEvents returns one page of a workflow's event history, via the
+// run_id and page_token query params.
+func (h *Handler) GetWorkflowEvents(c *fiber.Ctx) error {
+	ctx := c.Context()
+	workflowID := c.Params("id")
+	runID := c.Query("run_id")
+	pageToken := c.Query("page_token")
+
+	events, nextPageToken, err := GetWorkflowEvents(ctx, h.temporalClient, workflowID, runID, pageToken)
+	if err != nil {
+		h.logger.Error("failed to get workflow events", err, "workflow_id", workflowID)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, map[string]interface{}{
+		"events":          events,
+		"next_page_token": nextPageToken,
+	}, nil))
+}
+
+// GetTraceDetails returns trace details from the configured trace source.
 func (h *Handler) GetTraceDetails(c *fiber.Ctx) error {
 	ctx := c.Context()
 	traceID := c.Params("id")
 
-	details, err := GetTraceDetails(ctx, h.jaegerURL, traceID)
+	details, err := h.traces.GetTrace(ctx, traceID)
 	if err != nil {
 		h.logger.Error("failed to get trace details", err, "trace_id", traceID)
 		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
@@ -184,12 +441,27 @@ func (h *Handler) GetTraceDetails(c *fiber.Ctx) error {
 	return c.JSON(h.newResponse(true, details, nil))
 }
 
+// GetTraceAnalysis returns the critical path, per-service stats, service
+// dependency graph and detected anti-patterns for a trace.
+func (h *Handler) GetTraceAnalysis(c *fiber.Ctx) error {
+	ctx := c.Context()
+	traceID := c.Params("id")
+
+	detail, err := h.traces.GetTrace(ctx, traceID)
+	if err != nil {
+		h.logger.Error("failed to get trace details", err, "trace_id", traceID)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, AnalyzeTrace(detail), nil))
+}
+
 // ListRecentEvents returns recent events from Redpanda.
 func (h *Handler) ListRecentEvents(c *fiber.Ctx) error {
 	ctx := c.Context()
 	limit := c.QueryInt("limit", 100)
 
-	events, err := ListRecentEvents(ctx, h.redpandaClient, limit)
+	events, err := ListRecentEvents(ctx, h.redpandaClient, h.schemaDecoder, limit)
 	if err != nil {
 		h.logger.Error("failed to list recent events", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
@@ -198,6 +470,141 @@ func (h *Handler) ListRecentEvents(c *fiber.Ctx) error {
 	return c.JSON(h.newResponse(true, events, nil))
 }
 
+// TailEvents streams a topic's messages, merged into timestamp order across
+// partitions, as Server-Sent Events. The query params "start" (earliest,
+// latest, timestamp or offset; default earliest), "timestamp" (RFC3339, with
+// start=timestamp) and "offset" (with start=offset) pick the starting
+// position. The first event is always a "ready" event carrying the session
+// ID the client must echo to AckTailedEvent as it consumes each batch.
+func (h *Handler) TailEvents(c *fiber.Ctx) error {
+	topic := c.Query("topic")
+	if topic == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, fmt.Errorf("topic is required")))
+	}
+
+	start, err := parseTailStart(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, err))
+	}
+
+	// The stream writer below runs after this handler returns, so it can't
+	// be bound to c.Context() (which is reused/reset once the handler
+	// returns); tailer.Close() in the writer's deferred cleanup is what
+	// actually bounds its lifetime.
+	tailer, err := NewEventTailer(context.Background(), h.redpandaClient, h.schemaDecoder, topic, start)
+	if err != nil {
+		h.logger.Error("failed to start event tailer", err, "topic", topic)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	h.tailersMu.Lock()
+	h.tailers[tailer.SessionID] = tailer
+	h.tailersMu.Unlock()
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer func() {
+			h.tailersMu.Lock()
+			delete(h.tailers, tailer.SessionID)
+			h.tailersMu.Unlock()
+			tailer.Close()
+		}()
+
+		select {
+		case <-tailer.Ready():
+		case err := <-tailer.Errs():
+			writeSSE(w, "error", map[string]string{"error": err.Error()})
+			return
+		}
+		if err := writeSSE(w, "ready", map[string]string{"session_id": tailer.SessionID}); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-tailer.Events():
+				if !ok {
+					return
+				}
+				if writeSSE(w, "message", event) != nil {
+					return
+				}
+			case err := <-tailer.Errs():
+				writeSSE(w, "error", map[string]string{"error": err.Error()})
+				return
+			}
+		}
+	}))
+
+	return nil
+}
+
+// writeSSE writes one Server-Sent Events frame and flushes it immediately,
+// so the client sees each event as it arrives rather than once a buffer
+// fills.
+func writeSSE(w *bufio.Writer, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// parseTailStart builds an EventTailStart from a TailEvents request's query
+// params.
+func parseTailStart(c *fiber.Ctx) (EventTailStart, error) {
+	switch kind := TailStartKind(c.Query("start", string(TailFromEarliest))); kind {
+	case TailFromEarliest, TailFromLatest:
+		return EventTailStart{Kind: kind}, nil
+	case TailFromTimestamp:
+		ts, err := time.Parse(time.RFC3339, c.Query("timestamp"))
+		if err != nil {
+			return EventTailStart{}, fmt.Errorf("invalid timestamp: %w", err)
+		}
+		return EventTailStart{Kind: kind, Timestamp: ts}, nil
+	case TailFromOffset:
+		offset, err := strconv.ParseInt(c.Query("offset"), 10, 64)
+		if err != nil {
+			return EventTailStart{}, fmt.Errorf("invalid offset: %w", err)
+		}
+		return EventTailStart{Kind: kind, Offset: offset}, nil
+	default:
+		return EventTailStart{}, fmt.Errorf("unknown start kind %q", kind)
+	}
+}
+
+// AckTailedEvent records that the UI has durably processed a tailed topic up
+// through a partition's offset, so EventTailer knows it's safe to advance
+// that partition's checkpoint.
+func (h *Handler) AckTailedEvent(c *fiber.Ctx) error {
+	sessionID := c.Params("session")
+
+	h.tailersMu.Lock()
+	tailer, ok := h.tailers[sessionID]
+	h.tailersMu.Unlock()
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(h.newResponse(false, nil, fmt.Errorf("no active tail session %s", sessionID)))
+	}
+
+	var req struct {
+		Partition int   `json:"partition"`
+		Offset    int64 `json:"offset"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, err))
+	}
+
+	tailer.Ack(req.Partition, req.Offset)
+
+	return c.JSON(h.newResponse(true, tailer.Checkpoint(), nil))
+}
+
 // generateTraceID generates a unique trace ID for request correlation.
 func generateTraceID() string {
 	return uuid.New().String()
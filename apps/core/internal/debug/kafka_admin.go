@@ -0,0 +1,425 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"iterateswarm-core/internal/redpanda"
+)
+
+// adminClient builds a kafka-go admin Client from rc's own broker/TLS/SASL
+// configuration, so the debug admin surface works against whatever cluster
+// the rest of the process is already talking to instead of assuming
+// localhost.
+func adminClient(rc *redpanda.Client) *kafka.Client {
+	dialer := rc.Dialer()
+	return &kafka.Client{
+		Addr:    kafka.TCP(rc.Brokers()...),
+		Timeout: 10 * time.Second,
+		Transport: &kafka.Transport{
+			TLS:  dialer.TLS,
+			SASL: dialer.SASLMechanism,
+		},
+	}
+}
+
+// ClusterDescription summarizes a cluster: its controller broker, full
+// broker list, and configured ACLs.
+type ClusterDescription struct {
+	ControllerID int              `json:"controller_id"`
+	Brokers      []BrokerInfo     `json:"brokers"`
+	ACLs         []ACLDescription `json:"acls,omitempty"`
+}
+
+// BrokerInfo is one broker in a cluster's metadata.
+type BrokerInfo struct {
+	ID   int    `json:"id"`
+	Host string `json:"host"`
+	Port int    `json:"port"`
+	Rack string `json:"rack,omitempty"`
+}
+
+// ACLDescription is one ACL binding.
+type ACLDescription struct {
+	Principal      string `json:"principal"`
+	ResourceType   string `json:"resource_type"`
+	ResourceName   string `json:"resource_name"`
+	Operation      string `json:"operation"`
+	PermissionType string `json:"permission_type"`
+}
+
+// DescribeCluster returns the cluster's controller broker, full broker list
+// and ACLs. ACLs are omitted rather than erroring if the cluster doesn't
+// have ACL authorization enabled.
+func DescribeCluster(ctx context.Context, rc *redpanda.Client) (*ClusterDescription, error) {
+	admin := adminClient(rc)
+
+	metadata, err := admin.Metadata(ctx, &kafka.MetadataRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch cluster metadata: %w", err)
+	}
+
+	desc := &ClusterDescription{ControllerID: metadata.Controller.ID}
+	for _, b := range metadata.Brokers {
+		desc.Brokers = append(desc.Brokers, BrokerInfo{ID: b.ID, Host: b.Host, Port: b.Port, Rack: b.Rack})
+	}
+
+	aclResp, err := admin.DescribeACLs(ctx, &kafka.DescribeACLsRequest{
+		Filter: kafka.ACLFilter{
+			ResourceTypeFilter:        kafka.ResourceTypeAny,
+			ResourcePatternTypeFilter: kafka.PatternTypeAny,
+			PermissionType:            kafka.ACLPermissionTypeAny,
+			Operation:                 kafka.ACLOperationTypeAny,
+		},
+	})
+	if err != nil {
+		return desc, nil
+	}
+	for _, r := range aclResp.Resources {
+		for _, a := range r.ACLs {
+			desc.ACLs = append(desc.ACLs, ACLDescription{
+				Principal:      a.Principal,
+				ResourceType:   r.ResourceType.String(),
+				ResourceName:   r.ResourceName,
+				Operation:      a.Operation.String(),
+				PermissionType: a.PermissionType.String(),
+			})
+		}
+	}
+
+	return desc, nil
+}
+
+// TopicDescription is a topic's configs and per-partition placement/ISR
+// info, as returned by DescribeTopics.
+type TopicDescription struct {
+	Name       string                 `json:"name"`
+	Configs    map[string]string      `json:"configs,omitempty"`
+	Partitions []PartitionDescription `json:"partitions"`
+}
+
+// PartitionDescription is one partition's leader, replica set and
+// in-sync-replica set.
+type PartitionDescription struct {
+	PartitionID int   `json:"partition_id"`
+	Leader      int   `json:"leader"`
+	Replicas    []int `json:"replicas"`
+	ISR         []int `json:"isr"`
+}
+
+// DescribeTopics returns configs and per-partition placement for each named
+// topic.
+func DescribeTopics(ctx context.Context, rc *redpanda.Client, names []string) ([]TopicDescription, error) {
+	admin := adminClient(rc)
+
+	metadata, err := admin.Metadata(ctx, &kafka.MetadataRequest{Topics: names})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch topic metadata: %w", err)
+	}
+
+	resources := make([]kafka.DescribeConfigRequestResource, len(names))
+	for i, name := range names {
+		resources[i] = kafka.DescribeConfigRequestResource{
+			ResourceType: kafka.ResourceTypeTopic,
+			ResourceName: name,
+		}
+	}
+	configResp, err := admin.DescribeConfigs(ctx, &kafka.DescribeConfigsRequest{Resources: resources})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe topic configs: %w", err)
+	}
+	configsByTopic := make(map[string]map[string]string, len(configResp.Resources))
+	for _, r := range configResp.Resources {
+		configs := make(map[string]string, len(r.ConfigEntries))
+		for _, e := range r.ConfigEntries {
+			configs[e.ConfigName] = e.ConfigValue
+		}
+		configsByTopic[r.ResourceName] = configs
+	}
+
+	descriptions := make([]TopicDescription, 0, len(metadata.Topics))
+	for _, t := range metadata.Topics {
+		desc := TopicDescription{Name: t.Name, Configs: configsByTopic[t.Name]}
+		for _, p := range t.Partitions {
+			desc.Partitions = append(desc.Partitions, PartitionDescription{
+				PartitionID: p.ID,
+				Leader:      p.Leader.ID,
+				Replicas:    brokerIDs(p.Replicas),
+				ISR:         brokerIDs(p.Isr),
+			})
+		}
+		descriptions = append(descriptions, desc)
+	}
+
+	return descriptions, nil
+}
+
+func brokerIDs(brokers []kafka.Broker) []int {
+	ids := make([]int, len(brokers))
+	for i, b := range brokers {
+		ids[i] = b.ID
+	}
+	return ids
+}
+
+// CreateTopic creates topic with the given partition count and replication
+// factor.
+func CreateTopic(ctx context.Context, rc *redpanda.Client, topic string, partitions, replicationFactor int) error {
+	admin := adminClient(rc)
+	_, err := admin.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{
+			{
+				Topic:             topic,
+				NumPartitions:     partitions,
+				ReplicationFactor: replicationFactor,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create topic %s: %w", topic, err)
+	}
+	return nil
+}
+
+// DeleteTopic deletes topic.
+func DeleteTopic(ctx context.Context, rc *redpanda.Client, topic string) error {
+	admin := adminClient(rc)
+	_, err := admin.DeleteTopics(ctx, &kafka.DeleteTopicsRequest{Topics: []string{topic}})
+	if err != nil {
+		return fmt.Errorf("failed to delete topic %s: %w", topic, err)
+	}
+	return nil
+}
+
+// AlterConfigs sets topic's dynamic configs (e.g. retention.ms,
+// cleanup.policy) to the given values.
+func AlterConfigs(ctx context.Context, rc *redpanda.Client, topic string, configs map[string]string) error {
+	admin := adminClient(rc)
+
+	entries := make([]kafka.AlterConfigRequestConfig, 0, len(configs))
+	for name, value := range configs {
+		entries = append(entries, kafka.AlterConfigRequestConfig{ConfigName: name, ConfigValue: value})
+	}
+
+	_, err := admin.AlterConfigs(ctx, &kafka.AlterConfigsRequest{
+		Resources: []kafka.AlterConfigRequestResource{
+			{
+				ResourceType: kafka.ResourceTypeTopic,
+				ResourceName: topic,
+				Configs:      entries,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to alter configs for topic %s: %w", topic, err)
+	}
+	return nil
+}
+
+// PartitionAssignment is the desired (or current) set of broker IDs hosting
+// one partition's replicas.
+type PartitionAssignment struct {
+	Topic       string `json:"topic"`
+	PartitionID int    `json:"partition_id"`
+	BrokerIDs   []int  `json:"broker_ids"`
+}
+
+// AlterPartitionReassignments moves topic's partitions onto the brokers
+// listed in assignments, letting operators rebalance partitions across the
+// cluster.
+func AlterPartitionReassignments(ctx context.Context, rc *redpanda.Client, assignments []PartitionAssignment) error {
+	admin := adminClient(rc)
+
+	reassignments := make([]kafka.AlterPartitionReassignmentsRequestAssignment, len(assignments))
+	for i, a := range assignments {
+		brokerIDs32 := make([]int32, len(a.BrokerIDs))
+		for j, id := range a.BrokerIDs {
+			brokerIDs32[j] = int32(id)
+		}
+		reassignments[i] = kafka.AlterPartitionReassignmentsRequestAssignment{
+			Topic:     a.Topic,
+			Partition: a.PartitionID,
+			BrokerIDs: brokerIDs32,
+		}
+	}
+
+	resp, err := admin.AlterPartitionReassignments(ctx, &kafka.AlterPartitionReassignmentsRequest{
+		Assignments: reassignments,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to alter partition reassignments: %w", err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("partition reassignment rejected: %w", resp.Error)
+	}
+	return nil
+}
+
+// PartitionReassignmentStatus reports an in-progress reassignment for one
+// partition.
+type PartitionReassignmentStatus struct {
+	Topic            string `json:"topic"`
+	PartitionID      int    `json:"partition_id"`
+	Replicas         []int  `json:"replicas"`
+	AddingReplicas   []int  `json:"adding_replicas,omitempty"`
+	RemovingReplicas []int  `json:"removing_replicas,omitempty"`
+}
+
+// ListPartitionReassignments returns the in-progress reassignments for the
+// given topics, or for every topic with a pending reassignment if topics is
+// empty.
+func ListPartitionReassignments(ctx context.Context, rc *redpanda.Client, topics []string) ([]PartitionReassignmentStatus, error) {
+	admin := adminClient(rc)
+
+	resp, err := admin.ListPartitionReassignments(ctx, &kafka.ListPartitionReassignmentsRequest{Topics: topics})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partition reassignments: %w", err)
+	}
+
+	var statuses []PartitionReassignmentStatus
+	for _, t := range resp.Topics {
+		for _, p := range t.Partitions {
+			statuses = append(statuses, PartitionReassignmentStatus{
+				Topic:            t.Topic,
+				PartitionID:      p.PartitionID,
+				Replicas:         int32sToInts(p.Replicas),
+				AddingReplicas:   int32sToInts(p.AddingReplicas),
+				RemovingReplicas: int32sToInts(p.RemovingReplicas),
+			})
+		}
+	}
+	return statuses, nil
+}
+
+func int32sToInts(values []int32) []int {
+	ints := make([]int, len(values))
+	for i, v := range values {
+		ints[i] = int(v)
+	}
+	return ints
+}
+
+// PartitionOffsetLag is one partition's current consumer offset, the
+// partition's log-end offset, and the resulting lag.
+type PartitionOffsetLag struct {
+	PartitionID     int   `json:"partition_id"`
+	CommittedOffset int64 `json:"committed_offset"`
+	EndOffset       int64 `json:"end_offset"`
+	Lag             int64 `json:"lag"`
+}
+
+// ConsumerGroupDescription is a consumer group's member assignments and
+// per-partition offset lag.
+type ConsumerGroupDescription struct {
+	GroupID string                          `json:"group_id"`
+	State   string                          `json:"state"`
+	Members []ConsumerGroupMember           `json:"members"`
+	Lag     map[string][]PartitionOffsetLag `json:"lag"`
+}
+
+// ConsumerGroupMember is one member of a consumer group and the
+// topic-partitions assigned to it.
+type ConsumerGroupMember struct {
+	MemberID   string   `json:"member_id"`
+	ClientID   string   `json:"client_id"`
+	ClientHost string   `json:"client_host"`
+	Topics     []string `json:"topics"`
+}
+
+// ConsumerGroupDescribe returns groupID's member assignments and current
+// offsets/lag per partition, computed as each partition's log-end offset
+// minus the group's last committed offset.
+func ConsumerGroupDescribe(ctx context.Context, rc *redpanda.Client, groupID string) (*ConsumerGroupDescription, error) {
+	admin := adminClient(rc)
+
+	groupResp, err := admin.DescribeGroups(ctx, &kafka.DescribeGroupsRequest{GroupIDs: []string{groupID}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe consumer group %s: %w", groupID, err)
+	}
+	if len(groupResp.Groups) == 0 {
+		return nil, fmt.Errorf("consumer group %s not found", groupID)
+	}
+	group := groupResp.Groups[0]
+
+	desc := &ConsumerGroupDescription{
+		GroupID: groupID,
+		State:   group.GroupState,
+		Lag:     make(map[string][]PartitionOffsetLag),
+	}
+
+	topicSet := make(map[string]bool)
+	for _, m := range group.Members {
+		member := ConsumerGroupMember{
+			MemberID:   m.MemberID,
+			ClientID:   m.ClientID,
+			ClientHost: m.ClientHost,
+		}
+		for _, assignment := range m.MemberAssignments.Topics {
+			member.Topics = append(member.Topics, assignment.Topic)
+			topicSet[assignment.Topic] = true
+		}
+		desc.Members = append(desc.Members, member)
+	}
+
+	if len(topicSet) == 0 {
+		return desc, nil
+	}
+
+	topics := make([]string, 0, len(topicSet))
+	for topic := range topicSet {
+		topics = append(topics, topic)
+	}
+
+	offsetResp, err := admin.OffsetFetch(ctx, &kafka.OffsetFetchRequest{GroupID: groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch committed offsets for group %s: %w", groupID, err)
+	}
+
+	for topic, partitions := range offsetResp.Topics {
+		endOffsets, err := topicEndOffsets(ctx, admin, topic, partitions)
+		if err != nil {
+			return nil, err
+		}
+
+		lag := make([]PartitionOffsetLag, 0, len(partitions))
+		for _, p := range partitions {
+			end := endOffsets[p.Partition]
+			lag = append(lag, PartitionOffsetLag{
+				PartitionID:     p.Partition,
+				CommittedOffset: p.CommittedOffset,
+				EndOffset:       end,
+				Lag:             end - p.CommittedOffset,
+			})
+		}
+		desc.Lag[topic] = lag
+	}
+
+	return desc, nil
+}
+
+// topicEndOffsets returns the log-end (latest) offset for each partition of
+// topic that appears in partitions.
+func topicEndOffsets(ctx context.Context, admin *kafka.Client, topic string, partitions []kafka.OffsetFetchPartition) (map[int]int64, error) {
+	ids := make([]int, len(partitions))
+	for i, p := range partitions {
+		ids[i] = p.Partition
+	}
+
+	resp, err := admin.ListOffsets(ctx, &kafka.ListOffsetsRequest{
+		Topics: map[string][]kafka.OffsetRequest{
+			topic: kafka.LastOffsetOf(ids...),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list end offsets for topic %s: %w", topic, err)
+	}
+
+	endOffsets := make(map[int]int64, len(ids))
+	for _, partitionOffset := range resp.Topics[topic] {
+		endOffsets[partitionOffset.Partition] = partitionOffset.LastOffset
+	}
+	return endOffsets, nil
+}
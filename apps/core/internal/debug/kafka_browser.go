@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
 
 	"iterateswarm-core/internal/redpanda"
@@ -40,12 +42,38 @@ type KafkaMessage struct {
 	Value     string            `json:"value"`
 	Headers   map[string]string `json:"headers,omitempty"`
 	Timestamp time.Time         `json:"timestamp"`
+
+	// SchemaID, SchemaSubject, SchemaVersion and ValueJSON are populated
+	// when a SchemaRegistryDecoder is configured and Value starts with the
+	// Confluent wire-format magic byte; Value itself is left as the raw
+	// string either way.
+	SchemaID      int             `json:"schema_id,omitempty"`
+	SchemaSubject string          `json:"schema_subject,omitempty"`
+	SchemaVersion int             `json:"schema_version,omitempty"`
+	ValueJSON     json.RawMessage `json:"value_json,omitempty"`
+}
+
+// applySchemaDecode decodes raw against decoder (if non-nil and raw looks
+// like a Confluent wire-format message) and, on success, fills in msg's
+// SchemaID/SchemaSubject/SchemaVersion/ValueJSON. Decode errors are
+// returned for the caller to log but never prevent msg.Value (set by the
+// caller beforehand) from standing on its own.
+func (msg *KafkaMessage) applySchemaDecode(ctx context.Context, decoder *SchemaRegistryDecoder, raw []byte) error {
+	if decoder == nil {
+		return nil
+	}
+	decoded, ok, err := decoder.Decode(ctx, raw)
+	if err != nil || !ok {
+		return err
+	}
+	msg.SchemaID, msg.SchemaSubject, msg.SchemaVersion, msg.ValueJSON =
+		decoded.SchemaID, decoded.SchemaSubject, decoded.SchemaVersion, decoded.ValueJSON
+	return nil
 }
 
 // ListKafkaTopics returns a list of all Kafka topics with metadata.
 func ListKafkaTopics(ctx context.Context, client *redpanda.Client) ([]TopicMetadata, error) {
-	// Connect to Kafka to get list of topics (use internal port for Docker)
-	conn, err := kafka.Dial("tcp", "localhost:19092")
+	conn, err := client.Dialer().DialContext(ctx, "tcp", client.Brokers()[0])
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Kafka: %w", err)
 	}
@@ -99,12 +127,18 @@ func getReplicationFactor(partitions []kafka.Partition) int {
 	return len(partitions[0].Replicas)
 }
 
-// GetKafkaTopicMessages returns messages from a specific Kafka topic with pagination.
-func GetKafkaTopicMessages(ctx context.Context, client *redpanda.Client, topicName string, offset, limit int) ([]KafkaMessage, error) {
+// GetKafkaTopicMessages returns messages from a specific Kafka topic with
+// pagination. Each call uses its own ephemeral consumer group so concurrent
+// or repeated requests don't share (and fight over) committed offsets — for
+// a long-lived, ordered, multi-partition live view use EventTailer instead.
+// decoder may be nil, in which case messages are returned with only their
+// raw Value.
+func GetKafkaTopicMessages(ctx context.Context, client *redpanda.Client, decoder *SchemaRegistryDecoder, topicName string, offset, limit int) ([]KafkaMessage, error) {
 	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:  []string{"localhost:19092"},
+		Brokers:  client.Brokers(),
+		Dialer:   client.Dialer(),
 		Topic:    topicName,
-		GroupID:  "debug-browser",
+		GroupID:  "debug-browser-" + uuid.New().String(),
 		MinBytes: 10e3,
 		MaxBytes: 10e6,
 	})
@@ -140,6 +174,9 @@ func GetKafkaTopicMessages(ctx context.Context, client *redpanda.Client, topicNa
 
 		if len(kafkaMsg.Value) > 0 {
 			msg.Value = string(kafkaMsg.Value)
+			if err := msg.applySchemaDecode(ctx, decoder, kafkaMsg.Value); err != nil {
+				log.Printf("Failed to decode message against schema registry: %v", err)
+			}
 		}
 
 		if len(kafkaMsg.Headers) > 0 {
@@ -191,12 +228,35 @@ type Event struct {
 	Value     string            `json:"value"`
 	Headers   map[string]string `json:"headers,omitempty"`
 	Timestamp time.Time         `json:"timestamp"`
+
+	SchemaID      int             `json:"schema_id,omitempty"`
+	SchemaSubject string          `json:"schema_subject,omitempty"`
+	SchemaVersion int             `json:"schema_version,omitempty"`
+	ValueJSON     json.RawMessage `json:"value_json,omitempty"`
 }
 
-// ListRecentEvents returns recent events from Redpanda.
-func ListRecentEvents(ctx context.Context, client *redpanda.Client, limit int) ([]Event, error) {
-	// Connect to Kafka to list topics
-	conn, err := kafka.Dial("tcp", "localhost:19092")
+// applySchemaDecode is Event's counterpart to KafkaMessage.applySchemaDecode.
+func (e *Event) applySchemaDecode(ctx context.Context, decoder *SchemaRegistryDecoder, raw []byte) error {
+	if decoder == nil {
+		return nil
+	}
+	decoded, ok, err := decoder.Decode(ctx, raw)
+	if err != nil || !ok {
+		return err
+	}
+	e.SchemaID, e.SchemaSubject, e.SchemaVersion, e.ValueJSON =
+		decoded.SchemaID, decoded.SchemaSubject, decoded.SchemaVersion, decoded.ValueJSON
+	return nil
+}
+
+// ListRecentEvents returns recent events from Redpanda. It's a best-effort
+// snapshot for a quick look across every topic; each call uses its own
+// ephemeral consumer group so repeated requests don't share committed
+// offsets. For a live, ordered, ack-checkpointed view of a single topic use
+// EventTailer instead. decoder may be nil, in which case events are
+// returned with only their raw Value.
+func ListRecentEvents(ctx context.Context, client *redpanda.Client, decoder *SchemaRegistryDecoder, limit int) ([]Event, error) {
+	conn, err := client.Dialer().DialContext(ctx, "tcp", client.Brokers()[0])
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Kafka: %w", err)
 	}
@@ -219,9 +279,10 @@ func ListRecentEvents(ctx context.Context, client *redpanda.Client, limit int) (
 	// Read messages from each topic
 	for topic := range topicSet {
 		reader := kafka.NewReader(kafka.ReaderConfig{
-			Brokers:  []string{"localhost:19092"},
+			Brokers:  client.Brokers(),
+			Dialer:   client.Dialer(),
 			Topic:    topic,
-			GroupID:  "debug-events-consumer",
+			GroupID:  "debug-events-consumer-" + uuid.New().String(),
 			MinBytes: 10e3,
 			MaxBytes: 10e6,
 		})
@@ -248,6 +309,9 @@ func ListRecentEvents(ctx context.Context, client *redpanda.Client, limit int) (
 
 			if len(kafkaMsg.Value) > 0 {
 				event.Value = string(kafkaMsg.Value)
+				if err := event.applySchemaDecode(ctx, decoder, kafkaMsg.Value); err != nil {
+					log.Printf("Failed to decode event against schema registry: %v", err)
+				}
 			}
 
 			if len(kafkaMsg.Headers) > 0 {
@@ -0,0 +1,63 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"iterateswarm-core/internal/redpanda"
+)
+
+// SetJobManager attaches the job manager used by ListKafkaJobs,
+// RegisterKafkaJob and StopKafkaJob. Nil is a valid value: the routes then
+// report an empty job list and a 503, respectively.
+func (h *Handler) SetJobManager(jobs *redpanda.JobManager) {
+	h.jobManager = jobs
+}
+
+// ListKafkaJobs returns the active filter-driven Kafka consumer jobs and
+// their processed/matched/dropped/lag counters.
+func (h *Handler) ListKafkaJobs(c *fiber.Ctx) error {
+	if h.jobManager == nil {
+		return c.JSON(h.newResponse(true, []redpanda.JobStats{}, nil))
+	}
+	return c.JSON(h.newResponse(true, h.jobManager.Jobs(), nil))
+}
+
+// RegisterKafkaJob registers a new filter-driven Kafka consumer job. The
+// job's consumer goroutine outlives this request, so it's started against
+// context.Background() rather than c.Context() (which is torn down once
+// the response is written) and only stops when StopKafkaJob is called.
+func (h *Handler) RegisterKafkaJob(c *fiber.Ctx) error {
+	if h.jobManager == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(h.newResponse(false, nil, fmt.Errorf("no job manager configured")))
+	}
+
+	var def redpanda.JobDefinition
+	if err := c.BodyParser(&def); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, err))
+	}
+
+	id, err := h.jobManager.RegisterJob(context.Background(), def)
+	if err != nil {
+		h.logger.Error("failed to register kafka job", err, "topic", def.Topic)
+		return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, map[string]string{"id": id}, nil))
+}
+
+// StopKafkaJob stops a registered Kafka consumer job.
+func (h *Handler) StopKafkaJob(c *fiber.Ctx) error {
+	if h.jobManager == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(h.newResponse(false, nil, fmt.Errorf("no job manager configured")))
+	}
+
+	id := c.Params("id")
+	if err := h.jobManager.StopJob(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, map[string]string{"id": id}, nil))
+}
@@ -0,0 +1,506 @@
+package debug
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/hamba/avro/v2"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/dynamic"
+)
+
+// SchemaType is the wire format a schema-registry schema describes.
+type SchemaType string
+
+const (
+	SchemaTypeAvro       SchemaType = "AVRO"
+	SchemaTypeProtobuf   SchemaType = "PROTOBUF"
+	SchemaTypeJSONSchema SchemaType = "JSON"
+)
+
+// SchemaInfo is a schema as returned by the registry's /subjects/.../versions
+// endpoints.
+type SchemaInfo struct {
+	Subject    string     `json:"subject"`
+	Version    int        `json:"version"`
+	ID         int        `json:"id"`
+	Schema     string     `json:"schema"`
+	SchemaType SchemaType `json:"schemaType"`
+}
+
+// DecodedValue is the schema metadata and decoded payload SchemaRegistryDecoder
+// attaches to a KafkaMessage/Event whose value starts with the Confluent
+// wire-format magic byte.
+type DecodedValue struct {
+	SchemaID      int             `json:"schema_id"`
+	SchemaSubject string          `json:"schema_subject,omitempty"`
+	SchemaVersion int             `json:"schema_version,omitempty"`
+	ValueJSON     json.RawMessage `json:"value_json,omitempty"`
+}
+
+// SchemaRegistryDecoder recognizes Confluent wire-format messages (magic
+// byte 0x00 + 4-byte big-endian schema ID + payload), resolves the schema
+// from a Confluent-compatible schema registry, and decodes Avro, Protobuf
+// or JSON Schema payloads into generic JSON for display in the debug UI.
+// Resolved schemas are cached by ID in an LRU, since registry schemas are
+// immutable once published.
+type SchemaRegistryDecoder struct {
+	baseURL    string
+	httpClient *http.Client
+	cache      *schemaLRU
+}
+
+// NewSchemaRegistryDecoder creates a decoder against a Confluent-compatible
+// schema registry at baseURL (e.g. "http://localhost:8081"), caching up to
+// cacheSize resolved schemas. cacheSize <= 0 defaults to 256.
+func NewSchemaRegistryDecoder(baseURL string, cacheSize int) *SchemaRegistryDecoder {
+	if cacheSize <= 0 {
+		cacheSize = 256
+	}
+	return &SchemaRegistryDecoder{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      newSchemaLRU(cacheSize),
+	}
+}
+
+// Decode inspects raw for the Confluent wire-format magic byte. ok is false
+// (with a nil error) when raw doesn't start with it, so the caller can fall
+// back to treating it as a plain string.
+func (d *SchemaRegistryDecoder) Decode(ctx context.Context, raw []byte) (value DecodedValue, ok bool, err error) {
+	schemaID, payload, present := parseConfluentEnvelope(raw)
+	if !present {
+		return DecodedValue{}, false, nil
+	}
+
+	schema, err := d.resolve(ctx, schemaID)
+	if err != nil {
+		return DecodedValue{}, true, fmt.Errorf("failed to resolve schema %d: %w", schemaID, err)
+	}
+
+	valueJSON, err := schema.decode(payload)
+	if err != nil {
+		return DecodedValue{}, true, fmt.Errorf("failed to decode payload against schema %d: %w", schemaID, err)
+	}
+
+	return DecodedValue{
+		SchemaID:      schemaID,
+		SchemaSubject: schema.subject,
+		SchemaVersion: schema.version,
+		ValueJSON:     valueJSON,
+	}, true, nil
+}
+
+// ListSubjects returns every subject registered with the registry.
+func (d *SchemaRegistryDecoder) ListSubjects(ctx context.Context) ([]string, error) {
+	var subjects []string
+	if err := d.getJSON(ctx, "/subjects", &subjects); err != nil {
+		return nil, fmt.Errorf("failed to list subjects: %w", err)
+	}
+	return subjects, nil
+}
+
+// GetSchema returns a subject's schema at version (a version number, or
+// "latest").
+func (d *SchemaRegistryDecoder) GetSchema(ctx context.Context, subject, version string) (*SchemaInfo, error) {
+	var info SchemaInfo
+	path := fmt.Sprintf("/subjects/%s/versions/%s", subject, version)
+	if err := d.getJSON(ctx, path, &info); err != nil {
+		return nil, fmt.Errorf("failed to get schema %s/%s: %w", subject, version, err)
+	}
+	return &info, nil
+}
+
+// CompatibilityResult is the registry's response to a compatibility check.
+type CompatibilityResult struct {
+	IsCompatible bool `json:"is_compatible"`
+}
+
+// CheckCompatibility checks whether schemaText is compatible with subject's
+// version (a version number, or "latest") under the registry's configured
+// compatibility level.
+func (d *SchemaRegistryDecoder) CheckCompatibility(ctx context.Context, subject, version, schemaText string, schemaType SchemaType) (*CompatibilityResult, error) {
+	body, err := json.Marshal(struct {
+		Schema     string     `json:"schema"`
+		SchemaType SchemaType `json:"schemaType,omitempty"`
+	}{Schema: schemaText, SchemaType: schemaType})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal compatibility request: %w", err)
+	}
+
+	path := fmt.Sprintf("/compatibility/subjects/%s/versions/%s", subject, version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check compatibility for %s/%s: %w", subject, version, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("schema registry returned %d: %s", resp.StatusCode, data)
+	}
+
+	var result CompatibilityResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode compatibility response: %w", err)
+	}
+	return &result, nil
+}
+
+func (d *SchemaRegistryDecoder) getJSON(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("schema registry returned %d: %s", resp.StatusCode, data)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// resolvedSchema is a schema fetched from the registry, along with a decode
+// closure built once (parsing/compiling the schema is the expensive part)
+// and reused for every message that references it.
+type resolvedSchema struct {
+	subject string
+	version int
+	decode  func(payload []byte) (json.RawMessage, error)
+}
+
+// resolve fetches and caches the schema for id, building its decode closure
+// on first use.
+func (d *SchemaRegistryDecoder) resolve(ctx context.Context, id int) (*resolvedSchema, error) {
+	if cached, ok := d.cache.get(id); ok {
+		return cached, nil
+	}
+
+	var raw struct {
+		Schema     string     `json:"schema"`
+		SchemaType SchemaType `json:"schemaType"`
+	}
+	if err := d.getJSON(ctx, fmt.Sprintf("/schemas/ids/%d", id), &raw); err != nil {
+		return nil, err
+	}
+	if raw.SchemaType == "" {
+		raw.SchemaType = SchemaTypeAvro // the registry omits schemaType for its original (Avro) schemas
+	}
+
+	decodeFn, err := buildDecoder(raw.SchemaType, raw.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s decoder: %w", raw.SchemaType, err)
+	}
+
+	subject, version := d.resolveSubjectVersion(ctx, id)
+
+	resolved := &resolvedSchema{subject: subject, version: version, decode: decodeFn}
+	d.cache.put(id, resolved)
+	return resolved, nil
+}
+
+// resolveSubjectVersion best-effort resolves which subject/version a schema
+// ID belongs to, for display purposes only; failures here don't prevent
+// decoding, they just leave Subject/Version blank.
+func (d *SchemaRegistryDecoder) resolveSubjectVersion(ctx context.Context, id int) (subject string, version int) {
+	var subjects []string
+	if err := d.getJSON(ctx, fmt.Sprintf("/schemas/ids/%d/subjects", id), &subjects); err != nil || len(subjects) == 0 {
+		return "", 0
+	}
+
+	var latest SchemaInfo
+	if err := d.getJSON(ctx, fmt.Sprintf("/subjects/%s/versions/latest", subjects[0]), &latest); err != nil {
+		return subjects[0], 0
+	}
+	if latest.ID != id {
+		// The latest version isn't the one this message was written with;
+		// still surface the subject so the UI has something to link to.
+		return subjects[0], 0
+	}
+	return subjects[0], latest.Version
+}
+
+// buildDecoder compiles schemaText once into a reusable decode closure for
+// schemaType.
+func buildDecoder(schemaType SchemaType, schemaText string) (func([]byte) (json.RawMessage, error), error) {
+	switch schemaType {
+	case SchemaTypeAvro:
+		return buildAvroDecoder(schemaText)
+	case SchemaTypeProtobuf:
+		return buildProtobufDecoder(schemaText)
+	case SchemaTypeJSONSchema:
+		return buildJSONSchemaDecoder()
+	default:
+		return nil, fmt.Errorf("unsupported schema type %q", schemaType)
+	}
+}
+
+func buildAvroDecoder(schemaText string) (func([]byte) (json.RawMessage, error), error) {
+	schema, err := avro.Parse(schemaText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Avro schema: %w", err)
+	}
+
+	return func(payload []byte) (json.RawMessage, error) {
+		var generic interface{}
+		if err := avro.Unmarshal(schema, payload, &generic); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Avro payload: %w", err)
+		}
+		return json.Marshal(generic)
+	}, nil
+}
+
+func buildJSONSchemaDecoder() (func([]byte) (json.RawMessage, error), error) {
+	// The payload after the envelope is already a JSON document validated
+	// against the schema at publish time; re-validating it here would need
+	// a full JSON Schema validator, which is more than this debug view
+	// needs. Just confirm it's well-formed JSON.
+	return func(payload []byte) (json.RawMessage, error) {
+		if !json.Valid(payload) {
+			return nil, fmt.Errorf("payload is not valid JSON")
+		}
+		return json.RawMessage(payload), nil
+	}, nil
+}
+
+func buildProtobufDecoder(schemaText string) (func([]byte) (json.RawMessage, error), error) {
+	parser := protoparse.Parser{
+		Accessor: protoparse.FileContentsFromMap(map[string]string{"schema.proto": schemaText}),
+	}
+	files, err := parser.ParseFiles("schema.proto")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Protobuf schema: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("Protobuf schema produced no file descriptor")
+	}
+	file := files[0]
+
+	return func(payload []byte) (json.RawMessage, error) {
+		indexes, body, err := readMessageIndexes(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message indexes: %w", err)
+		}
+
+		msgDesc, err := resolveMessageType(file, indexes)
+		if err != nil {
+			return nil, err
+		}
+
+		msg := dynamic.NewMessage(msgDesc)
+		if err := msg.Unmarshal(body); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Protobuf payload: %w", err)
+		}
+		return msg.MarshalJSON()
+	}, nil
+}
+
+// resolveMessageType walks file's (possibly nested) message types using the
+// Confluent message-index path: indexes[0] selects a top-level message,
+// each subsequent index selects a nested message type within it.
+func resolveMessageType(file *desc.FileDescriptor, indexes []int) (*desc.MessageDescriptor, error) {
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("empty message index path")
+	}
+
+	types := file.GetMessageTypes()
+	if indexes[0] < 0 || indexes[0] >= len(types) {
+		return nil, fmt.Errorf("message index %d out of range (%d top-level types)", indexes[0], len(types))
+	}
+	msgDesc := types[indexes[0]]
+
+	for _, idx := range indexes[1:] {
+		nested := msgDesc.GetNestedMessageTypes()
+		if idx < 0 || idx >= len(nested) {
+			return nil, fmt.Errorf("nested message index %d out of range", idx)
+		}
+		msgDesc = nested[idx]
+	}
+	return msgDesc, nil
+}
+
+// parseConfluentEnvelope splits raw into its schema ID and payload if it
+// starts with the Confluent wire-format magic byte (0x00).
+func parseConfluentEnvelope(raw []byte) (schemaID int, payload []byte, ok bool) {
+	const magicByte = 0x00
+	const envelopeLen = 5 // 1 magic byte + 4-byte schema ID
+	if len(raw) < envelopeLen || raw[0] != magicByte {
+		return 0, nil, false
+	}
+	return int(binary.BigEndian.Uint32(raw[1:envelopeLen])), raw[envelopeLen:], true
+}
+
+// readMessageIndexes reads the Confluent protobuf message-index array from
+// the front of payload: a zig-zag varint count, followed by that many
+// zig-zag varint indexes. A count of zero is a shorthand for "the first
+// (and only) top-level message type", i.e. index path [0].
+func readMessageIndexes(payload []byte) (indexes []int, rest []byte, err error) {
+	count, n := binary.Varint(payload)
+	if n <= 0 {
+		return nil, nil, fmt.Errorf("invalid message index count")
+	}
+	payload = payload[n:]
+
+	if count == 0 {
+		return []int{0}, payload, nil
+	}
+
+	indexes = make([]int, count)
+	for i := range indexes {
+		v, n := binary.Varint(payload)
+		if n <= 0 {
+			return nil, nil, fmt.Errorf("invalid message index at position %d", i)
+		}
+		indexes[i] = int(v)
+		payload = payload[n:]
+	}
+	return indexes, payload, nil
+}
+
+// schemaLRU is a fixed-capacity, least-recently-used cache of resolved
+// schemas keyed by registry schema ID.
+type schemaLRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[int]*list.Element
+}
+
+type schemaLRUEntry struct {
+	id     int
+	schema *resolvedSchema
+}
+
+func newSchemaLRU(capacity int) *schemaLRU {
+	return &schemaLRU{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[int]*list.Element),
+	}
+}
+
+func (c *schemaLRU) get(id int) (*resolvedSchema, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*schemaLRUEntry).schema, true
+}
+
+func (c *schemaLRU) put(id int, schema *resolvedSchema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[id]; ok {
+		elem.Value.(*schemaLRUEntry).schema = schema
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&schemaLRUEntry{id: id, schema: schema})
+	c.entries[id] = elem
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*schemaLRUEntry).id)
+		}
+	}
+}
+
+// SetSchemaRegistry attaches the decoder used to enrich Kafka browser
+// messages/events and to back the /schemas routes. Nil is a valid value:
+// messages are then returned with only their raw Value, and the /schemas
+// routes report the registry as not configured.
+func (h *Handler) SetSchemaRegistry(decoder *SchemaRegistryDecoder) {
+	h.schemaDecoder = decoder
+}
+
+// ListSubjects returns every subject registered with the schema registry.
+func (h *Handler) ListSubjects(c *fiber.Ctx) error {
+	if h.schemaDecoder == nil {
+		return c.JSON(h.newResponse(false, nil, fmt.Errorf("schema registry not configured")))
+	}
+
+	subjects, err := h.schemaDecoder.ListSubjects(c.Context())
+	if err != nil {
+		h.logger.Error("failed to list schema subjects", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, subjects, nil))
+}
+
+// GetSchema returns a subject's schema at the requested version ("latest"
+// or a version number).
+func (h *Handler) GetSchema(c *fiber.Ctx) error {
+	if h.schemaDecoder == nil {
+		return c.JSON(h.newResponse(false, nil, fmt.Errorf("schema registry not configured")))
+	}
+
+	subject := c.Params("subject")
+	version := c.Params("version")
+
+	info, err := h.schemaDecoder.GetSchema(c.Context(), subject, version)
+	if err != nil {
+		h.logger.Error("failed to get schema", err, "subject", subject, "version", version)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, info, nil))
+}
+
+// CheckCompatibility checks a candidate schema's compatibility against a
+// subject's existing version ("latest" or a version number).
+func (h *Handler) CheckCompatibility(c *fiber.Ctx) error {
+	if h.schemaDecoder == nil {
+		return c.JSON(h.newResponse(false, nil, fmt.Errorf("schema registry not configured")))
+	}
+
+	subject := c.Params("subject")
+	version := c.Params("version")
+
+	var req struct {
+		Schema     string     `json:"schema"`
+		SchemaType SchemaType `json:"schema_type"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(h.newResponse(false, nil, err))
+	}
+
+	result, err := h.schemaDecoder.CheckCompatibility(c.Context(), subject, version, req.Schema, req.SchemaType)
+	if err != nil {
+		h.logger.Error("failed to check schema compatibility", err, "subject", subject, "version", version)
+		return c.Status(fiber.StatusInternalServerError).JSON(h.newResponse(false, nil, err))
+	}
+
+	return c.JSON(h.newResponse(true, result, nil))
+}
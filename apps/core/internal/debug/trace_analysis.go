@@ -0,0 +1,317 @@
+package debug
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// TraceAnalysis is the structural analysis AnalyzeTrace derives from a
+// TraceDetail: the critical path through the trace, per-service aggregates,
+// the inter-service call graph, and any detected anti-patterns.
+type TraceAnalysis struct {
+	TraceID              string             `json:"trace_id"`
+	CriticalPath         []CriticalPathSpan `json:"critical_path"`
+	CriticalPathDuration time.Duration      `json:"critical_path_duration"`
+	Services             []ServiceStats     `json:"services"`
+	Dependencies         []Edge             `json:"dependencies"`
+	AntiPatterns         []AntiPattern      `json:"anti_patterns,omitempty"`
+}
+
+// CriticalPathSpan is one hop of the critical path: the chain of
+// causally-dependent spans, root to leaf, that determines the trace's total
+// latency.
+type CriticalPathSpan struct {
+	SpanID        string        `json:"span_id"`
+	ServiceName   string        `json:"service_name"`
+	OperationName string        `json:"operation_name"`
+	ExclusiveTime time.Duration `json:"exclusive_time"`
+}
+
+// ServiceStats aggregates every span for one ServiceName within a trace.
+type ServiceStats struct {
+	Name       string        `json:"name"`
+	SpanCount  int           `json:"span_count"`
+	TotalTime  time.Duration `json:"total_time"`
+	SelfTime   time.Duration `json:"self_time"`
+	ErrorCount int           `json:"error_count"`
+}
+
+// Edge is one hop of the service dependency graph: From called To
+// CallCount times, with P50/P95 latencies across those calls.
+type Edge struct {
+	From      string        `json:"from"`
+	To        string        `json:"to"`
+	CallCount int           `json:"call_count"`
+	P50       time.Duration `json:"p50"`
+	P95       time.Duration `json:"p95"`
+}
+
+// AntiPattern flags a span or group of spans matching a known
+// performance-smell shape.
+type AntiPattern struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description"`
+	SpanIDs     []string `json:"span_ids"`
+}
+
+const (
+	antiPatternNPlusOne = "n_plus_one"
+	antiPatternLongTail = "long_tail"
+
+	nPlusOneThreshold = 10
+	longTailFraction  = 0.5
+)
+
+// AnalyzeTrace computes the critical path, per-service stats, service
+// dependency graph and anti-patterns for detail. It returns an
+// analysis with zero values (not nil) when detail has no spans.
+func AnalyzeTrace(detail *TraceDetail) *TraceAnalysis {
+	analysis := &TraceAnalysis{
+		TraceID:      detail.TraceID,
+		Services:     []ServiceStats{},
+		Dependencies: []Edge{},
+	}
+
+	if len(detail.Spans) == 0 {
+		return analysis
+	}
+
+	spansByID := make(map[string]*Span, len(detail.Spans))
+	childrenOf := make(map[string][]*Span)
+	var roots []*Span
+
+	for i := range detail.Spans {
+		spansByID[detail.Spans[i].SpanID] = &detail.Spans[i]
+	}
+	for i := range detail.Spans {
+		span := &detail.Spans[i]
+		if parent, ok := spansByID[span.ParentSpanID]; ok && span.ParentSpanID != "" {
+			childrenOf[parent.SpanID] = append(childrenOf[parent.SpanID], span)
+		} else {
+			roots = append(roots, span)
+		}
+	}
+
+	analysis.CriticalPath, analysis.CriticalPathDuration = criticalPath(roots, childrenOf)
+	analysis.Services = serviceStats(detail.Spans, childrenOf)
+	analysis.Dependencies = dependencyGraph(detail.Spans, spansByID)
+	analysis.AntiPatterns = detectAntiPatterns(detail, childrenOf)
+
+	return analysis
+}
+
+// criticalPath walks the parent-to-children graph starting at the earliest
+// root span, at each step following the child whose EndTime is latest (the
+// one that kept the trace open longest), and sums each hop's exclusive
+// duration.
+func criticalPath(roots []*Span, childrenOf map[string][]*Span) ([]CriticalPathSpan, time.Duration) {
+	if len(roots) == 0 {
+		return nil, 0
+	}
+
+	sort.Slice(roots, func(i, j int) bool { return roots[i].StartTime.Before(roots[j].StartTime) })
+	current := roots[0]
+
+	var path []CriticalPathSpan
+	var total time.Duration
+
+	for current != nil {
+		children := childrenOf[current.SpanID]
+		path = append(path, CriticalPathSpan{
+			SpanID:        current.SpanID,
+			ServiceName:   current.ServiceName,
+			OperationName: current.OperationName,
+			ExclusiveTime: exclusiveDuration(current, children),
+		})
+		total += path[len(path)-1].ExclusiveTime
+
+		var next *Span
+		for _, child := range children {
+			if next == nil || child.EndTime.After(next.EndTime) {
+				next = child
+			}
+		}
+		current = next
+	}
+
+	return path, total
+}
+
+// exclusiveDuration is span's own duration minus the time it overlaps with
+// children in the same service, so a span's self time isn't inflated by
+// work its own service did one level down (e.g. an internal sub-call).
+func exclusiveDuration(span *Span, children []*Span) time.Duration {
+	var overlap time.Duration
+	for _, child := range children {
+		if child.ServiceName != span.ServiceName {
+			continue
+		}
+		start := latest(span.StartTime, child.StartTime)
+		end := earliest(span.EndTime, child.EndTime)
+		if end.After(start) {
+			overlap += end.Sub(start)
+		}
+	}
+
+	excl := span.Duration - overlap
+	if excl < 0 {
+		return 0
+	}
+	return excl
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// serviceStats groups spans by ServiceName into SpanCount/TotalTime/
+// SelfTime/ErrorCount aggregates.
+func serviceStats(spans []Span, childrenOf map[string][]*Span) []ServiceStats {
+	statsByService := make(map[string]*ServiceStats)
+	var order []string
+
+	for i := range spans {
+		span := &spans[i]
+		stats, ok := statsByService[span.ServiceName]
+		if !ok {
+			stats = &ServiceStats{Name: span.ServiceName}
+			statsByService[span.ServiceName] = stats
+			order = append(order, span.ServiceName)
+		}
+
+		stats.SpanCount++
+		stats.TotalTime += span.Duration
+		stats.SelfTime += exclusiveDuration(span, childrenOf[span.SpanID])
+		if span.Tags["error"] == "true" {
+			stats.ErrorCount++
+		}
+	}
+
+	result := make([]ServiceStats, 0, len(order))
+	for _, name := range order {
+		result = append(result, *statsByService[name])
+	}
+	return result
+}
+
+// dependencyGraph derives a service-to-service call graph from every
+// parent-child span pair whose services differ.
+func dependencyGraph(spans []Span, spansByID map[string]*Span) []Edge {
+	durationsByPair := make(map[[2]string][]time.Duration)
+	var order [][2]string
+
+	for i := range spans {
+		span := &spans[i]
+		parent, ok := spansByID[span.ParentSpanID]
+		if !ok || parent.ServiceName == span.ServiceName {
+			continue
+		}
+
+		pair := [2]string{parent.ServiceName, span.ServiceName}
+		if _, seen := durationsByPair[pair]; !seen {
+			order = append(order, pair)
+		}
+		durationsByPair[pair] = append(durationsByPair[pair], span.Duration)
+	}
+
+	edges := make([]Edge, 0, len(order))
+	for _, pair := range order {
+		durations := durationsByPair[pair]
+		edges = append(edges, Edge{
+			From:      pair[0],
+			To:        pair[1],
+			CallCount: len(durations),
+			P50:       percentile(durations, 0.50),
+			P95:       percentile(durations, 0.95),
+		})
+	}
+	return edges
+}
+
+// percentile returns the p-th percentile (0-1) of durations using
+// nearest-rank interpolation; durations need not be pre-sorted.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// detectAntiPatterns flags two known smells: N+1 (more than
+// nPlusOneThreshold sibling spans sharing an OperationName under one
+// parent) and a long-tail span that alone accounts for more than
+// longTailFraction of the trace's total wall-clock duration.
+func detectAntiPatterns(detail *TraceDetail, childrenOf map[string][]*Span) []AntiPattern {
+	var patterns []AntiPattern
+
+	for parentID, children := range childrenOf {
+		byOperation := make(map[string][]string)
+		for _, child := range children {
+			byOperation[child.OperationName] = append(byOperation[child.OperationName], child.SpanID)
+		}
+		for operation, spanIDs := range byOperation {
+			if len(spanIDs) > nPlusOneThreshold {
+				patterns = append(patterns, AntiPattern{
+					Type:        antiPatternNPlusOne,
+					Description: fmt.Sprintf("parent span %s has %d sibling %q spans, suggesting an N+1 query pattern", parentID, len(spanIDs), operation),
+					SpanIDs:     spanIDs,
+				})
+			}
+		}
+	}
+
+	traceDuration := traceWallClock(detail.Spans)
+	if traceDuration > 0 {
+		for i := range detail.Spans {
+			span := &detail.Spans[i]
+			if float64(span.Duration) > longTailFraction*float64(traceDuration) {
+				patterns = append(patterns, AntiPattern{
+					Type:        antiPatternLongTail,
+					Description: fmt.Sprintf("span %s (%s) alone accounts for more than half the trace's duration", span.SpanID, span.OperationName),
+					SpanIDs:     []string{span.SpanID},
+				})
+			}
+		}
+	}
+
+	return patterns
+}
+
+func traceWallClock(spans []Span) time.Duration {
+	var min, max time.Time
+	for i := range spans {
+		if min.IsZero() || spans[i].StartTime.Before(min) {
+			min = spans[i].StartTime
+		}
+		if max.IsZero() || spans[i].EndTime.After(max) {
+			max = spans[i].EndTime
+		}
+	}
+	if min.IsZero() || max.IsZero() {
+		return 0
+	}
+	return max.Sub(min)
+}
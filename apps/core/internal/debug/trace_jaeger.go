@@ -0,0 +1,301 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// jaegerSource queries a Jaeger query-service HTTP API.
+type jaegerSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newJaegerSource(baseURL string) *jaegerSource {
+	if baseURL == "" {
+		baseURL = "http://localhost:16686"
+	}
+	return &jaegerSource{baseURL: baseURL, client: defaultHTTPClient()}
+}
+
+// JaegerTraceResponse represents the response from Jaeger API.
+type JaegerTraceResponse struct {
+	Data   []JaegerTrace `json:"data"`
+	Total  int           `json:"total"`
+	Limit  int           `json:"limit"`
+	Offset int           `json:"offset"`
+}
+
+// JaegerTrace represents a trace from Jaeger.
+type JaegerTrace struct {
+	TraceID   string                   `json:"traceID"`
+	Spans     []JaegerSpan             `json:"spans"`
+	Processes map[string]JaegerProcess `json:"processes"`
+	Warnings  []string                 `json:"warnings,omitempty"`
+}
+
+// JaegerSpan represents a span from Jaeger.
+type JaegerSpan struct {
+	TraceID       string        `json:"traceID"`
+	SpanID        string        `json:"spanID"`
+	ParentSpanID  string        `json:"parentSpanID,omitempty"`
+	OperationName string        `json:"operationName"`
+	References    []JaegerRef   `json:"references,omitempty"`
+	StartTime     int64         `json:"startTime"`
+	Duration      int64         `json:"duration"`
+	Tags          []JaegerTag   `json:"tags,omitempty"`
+	Logs          []JaegerLog   `json:"logs,omitempty"`
+	Process       JaegerProcess `json:"process,omitempty"`
+}
+
+// JaegerRef represents a span reference in Jaeger.
+type JaegerRef struct {
+	RefType string `json:"refType"`
+	TraceID string `json:"traceID"`
+	SpanID  string `json:"spanID"`
+}
+
+// JaegerTag represents a tag in Jaeger.
+type JaegerTag struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+	Type  string      `json:"type,omitempty"`
+}
+
+// JaegerLog represents a log entry in Jaeger.
+type JaegerLog struct {
+	Timestamp int64            `json:"timestamp"`
+	Fields    []JaegerLogField `json:"fields"`
+}
+
+// JaegerLogField represents a field in a log entry.
+type JaegerLogField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// JaegerProcess represents a process in Jaeger.
+type JaegerProcess struct {
+	ServiceName string      `json:"serviceName"`
+	Tags        []JaegerTag `json:"tags,omitempty"`
+}
+
+// GetTrace retrieves trace details from Jaeger.
+func (s *jaegerSource) GetTrace(ctx context.Context, traceID string) (*TraceDetail, error) {
+	url := fmt.Sprintf("%s/api/traces/%s", s.baseURL, traceID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trace: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Jaeger API error: %s", string(body))
+	}
+
+	var jaegerResp JaegerTraceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&jaegerResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(jaegerResp.Data) == 0 {
+		return nil, fmt.Errorf("trace not found: %s", traceID)
+	}
+
+	return convertJaegerTrace(jaegerResp.Data[0]), nil
+}
+
+// convertJaegerTrace converts a Jaeger trace to our TraceDetail format.
+func convertJaegerTrace(jaegerTrace JaegerTrace) *TraceDetail {
+	detail := &TraceDetail{
+		TraceID:  jaegerTrace.TraceID,
+		Spans:    make([]Span, 0, len(jaegerTrace.Spans)),
+		Services: make([]string, 0),
+		Status:   "ok",
+		Errors:   make([]string, 0),
+	}
+
+	serviceSet := make(map[string]bool)
+	var minTime, maxTime time.Time
+
+	for _, jaegerSpan := range jaegerTrace.Spans {
+		span := Span{
+			SpanID:        jaegerSpan.SpanID,
+			TraceID:       jaegerSpan.TraceID,
+			ParentSpanID:  jaegerSpan.ParentSpanID,
+			OperationName: jaegerSpan.OperationName,
+			StartTime:     time.UnixMicro(jaegerSpan.StartTime),
+			Duration:      time.Duration(jaegerSpan.Duration) * time.Microsecond,
+		}
+
+		if jaegerSpan.Process.ServiceName != "" {
+			span.ServiceName = jaegerSpan.Process.ServiceName
+			if !serviceSet[jaegerSpan.Process.ServiceName] {
+				serviceSet[jaegerSpan.Process.ServiceName] = true
+				detail.Services = append(detail.Services, jaegerSpan.Process.ServiceName)
+			}
+		}
+
+		span.Tags = make(map[string]string)
+		for _, tag := range jaegerSpan.Tags {
+			if tag.Value != nil {
+				span.Tags[tag.Key] = fmt.Sprintf("%v", tag.Value)
+				if tag.Key == "error" && tag.Value == true {
+					detail.Status = "error"
+					detail.Errors = append(detail.Errors, span.OperationName)
+				}
+			}
+		}
+
+		span.Logs = make([]SpanLog, 0)
+		for _, log := range jaegerSpan.Logs {
+			logEntry := SpanLog{
+				Timestamp: time.UnixMicro(log.Timestamp),
+				Fields:    make(map[string]string),
+			}
+			for _, field := range log.Fields {
+				logEntry.Fields[field.Key] = field.Value
+				if field.Key == "message" {
+					logEntry.Message = field.Value
+				}
+			}
+			span.Logs = append(span.Logs, logEntry)
+		}
+
+		for _, ref := range jaegerSpan.References {
+			span.References = append(span.References, SpanReference{
+				RefType: ref.RefType,
+				TraceID: ref.TraceID,
+				SpanID:  ref.SpanID,
+			})
+		}
+
+		span.EndTime = span.StartTime.Add(span.Duration)
+
+		detail.Spans = append(detail.Spans, span)
+
+		if minTime.IsZero() || span.StartTime.Before(minTime) {
+			minTime = span.StartTime
+		}
+		if maxTime.IsZero() || span.EndTime.After(maxTime) {
+			maxTime = span.EndTime
+		}
+	}
+
+	if !minTime.IsZero() && !maxTime.IsZero() {
+		detail.Duration = maxTime.Sub(minTime).String()
+		detail.StartTime = minTime.Format(time.RFC3339)
+	}
+
+	detail.SpanCount = len(detail.Spans)
+
+	return detail
+}
+
+// SearchTraces searches for traces by service and operation.
+func (s *jaegerSource) SearchTraces(ctx context.Context, service, operation string, limit int) ([]TraceSummary, error) {
+	url := fmt.Sprintf("%s/api/traces?service=%s", s.baseURL, service)
+	if operation != "" {
+		url += fmt.Sprintf("&operation=%s", operation)
+	}
+	if limit > 0 {
+		url += fmt.Sprintf("&limit=%d", limit)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch traces: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Jaeger API error: %s", string(body))
+	}
+
+	var jaegerResp JaegerTraceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&jaegerResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	summaries := make([]TraceSummary, 0, len(jaegerResp.Data))
+	for _, t := range jaegerResp.Data {
+		summary := TraceSummary{
+			TraceID:   t.TraceID,
+			SpanCount: len(t.Spans),
+			Services:  make([]string, 0),
+		}
+
+		for _, span := range t.Spans {
+			if span.Process.ServiceName != "" {
+				found := false
+				for _, svc := range summary.Services {
+					if svc == span.Process.ServiceName {
+						found = true
+						break
+					}
+				}
+				if !found {
+					summary.Services = append(summary.Services, span.Process.ServiceName)
+				}
+			}
+
+			if summary.OperationName == "" {
+				summary.OperationName = span.OperationName
+				summary.StartTime = time.UnixMicro(span.StartTime).Format(time.RFC3339)
+				summary.Duration = time.Duration(span.Duration) * time.Microsecond
+			}
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// ListServices lists available services from Jaeger.
+func (s *jaegerSource) ListServices(ctx context.Context) ([]string, error) {
+	url := fmt.Sprintf("%s/api/services", s.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch services: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Jaeger API error: %s", string(body))
+	}
+
+	var result struct {
+		Data []string `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Data, nil
+}
@@ -0,0 +1,106 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// otlpSource queries an OTLP-native backend's query API. The OTLP spec only
+// defines the ingestion protocol, not a query API, so collectors that
+// expose one (e.g. Grafana's OTLP query proxy) do so by re-exporting the
+// same Jaeger-compatible envelope Jaeger and Tempo use; we reuse that
+// decoding here rather than inventing a bespoke schema.
+type otlpSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newOTLPSource(baseURL string) *otlpSource {
+	if baseURL == "" {
+		baseURL = "http://localhost:4318"
+	}
+	return &otlpSource{baseURL: baseURL, client: defaultHTTPClient()}
+}
+
+// GetTrace retrieves a trace from the OTLP backend's query endpoint.
+func (s *otlpSource) GetTrace(ctx context.Context, traceID string) (*TraceDetail, error) {
+	url := fmt.Sprintf("%s/v1/traces/%s", s.baseURL, traceID)
+
+	var jaegerResp JaegerTraceResponse
+	if err := s.doGet(ctx, url, &jaegerResp); err != nil {
+		return nil, fmt.Errorf("failed to fetch trace: %w", err)
+	}
+	if len(jaegerResp.Data) == 0 {
+		return nil, fmt.Errorf("trace not found: %s", traceID)
+	}
+
+	return convertJaegerTrace(jaegerResp.Data[0]), nil
+}
+
+// SearchTraces finds recent traces by service and operation.
+func (s *otlpSource) SearchTraces(ctx context.Context, service, operation string, limit int) ([]TraceSummary, error) {
+	url := fmt.Sprintf("%s/v1/traces?service=%s", s.baseURL, service)
+	if operation != "" {
+		url += fmt.Sprintf("&operation=%s", operation)
+	}
+	if limit > 0 {
+		url += fmt.Sprintf("&limit=%d", limit)
+	}
+
+	var jaegerResp JaegerTraceResponse
+	if err := s.doGet(ctx, url, &jaegerResp); err != nil {
+		return nil, fmt.Errorf("failed to fetch traces: %w", err)
+	}
+
+	summaries := make([]TraceSummary, 0, len(jaegerResp.Data))
+	for _, t := range jaegerResp.Data {
+		detail := convertJaegerTrace(t)
+		summaries = append(summaries, TraceSummary{
+			TraceID:   detail.TraceID,
+			SpanCount: detail.SpanCount,
+			StartTime: detail.StartTime,
+			Services:  detail.Services,
+		})
+	}
+
+	return summaries, nil
+}
+
+// ListServices lists the service names the backend has recorded resource
+// attributes for.
+func (s *otlpSource) ListServices(ctx context.Context) ([]string, error) {
+	url := fmt.Sprintf("%s/v1/services", s.baseURL)
+
+	var result struct {
+		Data []string `json:"data"`
+	}
+	if err := s.doGet(ctx, url, &result); err != nil {
+		return nil, fmt.Errorf("failed to fetch services: %w", err)
+	}
+
+	return result.Data, nil
+}
+
+// doGet issues a GET request and decodes the JSON body into dest.
+func (s *otlpSource) doGet(ctx context.Context, url string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("OTLP query API error: %s", string(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(dest)
+}
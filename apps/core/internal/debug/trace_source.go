@@ -0,0 +1,46 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// TraceSource queries a distributed tracing backend for trace data. Each
+// backend (Jaeger, Zipkin, Tempo, OTLP) implements this against its own
+// native query API so the rest of the debug package, and LiteDebug's trace
+// viewer, stay backend-agnostic.
+type TraceSource interface {
+	// GetTrace retrieves the full trace (all spans) for traceID.
+	GetTrace(ctx context.Context, traceID string) (*TraceDetail, error)
+	// SearchTraces finds recent traces matching service/operation, most
+	// recent first, capped at limit.
+	SearchTraces(ctx context.Context, service, operation string, limit int) ([]TraceSummary, error)
+	// ListServices lists the service names the backend has seen spans for.
+	ListServices(ctx context.Context) ([]string, error)
+}
+
+// defaultHTTPClient is shared by the HTTP-based TraceSource implementations.
+func defaultHTTPClient() *http.Client {
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+// NewTraceSource constructs the TraceSource for backend ("jaeger", "zipkin",
+// "tempo" or "otlp"). baseURL falls back to the backend's conventional
+// default address when empty. An unrecognized backend is an error rather
+// than a silent fallback, so misconfiguration surfaces at startup.
+func NewTraceSource(backend, baseURL string) (TraceSource, error) {
+	switch backend {
+	case "", "jaeger":
+		return newJaegerSource(baseURL), nil
+	case "zipkin":
+		return newZipkinSource(baseURL), nil
+	case "tempo":
+		return newTempoSource(baseURL), nil
+	case "otlp":
+		return newOTLPSource(baseURL), nil
+	default:
+		return nil, fmt.Errorf("unknown trace backend: %s", backend)
+	}
+}
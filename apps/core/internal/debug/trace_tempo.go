@@ -0,0 +1,170 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// tempoSource queries Grafana Tempo. Tempo serves single-trace lookups
+// through a Jaeger-compatible envelope, but its search and service-listing
+// endpoints are its own TraceQL-based API, so only GetTrace can reuse the
+// Jaeger decoding.
+type tempoSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newTempoSource(baseURL string) *tempoSource {
+	if baseURL == "" {
+		baseURL = "http://localhost:3200"
+	}
+	return &tempoSource{baseURL: baseURL, client: defaultHTTPClient()}
+}
+
+// GetTrace retrieves a trace from Tempo's Jaeger-compatible endpoint.
+func (s *tempoSource) GetTrace(ctx context.Context, traceID string) (*TraceDetail, error) {
+	url := fmt.Sprintf("%s/api/traces/%s", s.baseURL, traceID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch trace: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Tempo API error: %s", string(body))
+	}
+
+	var jaegerResp JaegerTraceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&jaegerResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(jaegerResp.Data) == 0 {
+		return nil, fmt.Errorf("trace not found: %s", traceID)
+	}
+
+	return convertJaegerTrace(jaegerResp.Data[0]), nil
+}
+
+// tempoSearchResponse is the shape of Tempo's /api/search response.
+type tempoSearchResponse struct {
+	Traces []tempoSearchTrace `json:"traces"`
+}
+
+type tempoSearchTrace struct {
+	TraceID           string `json:"traceID"`
+	RootServiceName   string `json:"rootServiceName"`
+	RootTraceName     string `json:"rootTraceName"`
+	StartTimeUnixNano string `json:"startTimeUnixNano"`
+	DurationMs        int64  `json:"durationMs"`
+	SpanSet           struct {
+		Spans []struct {
+			SpanID string `json:"spanID"`
+		} `json:"spans"`
+	} `json:"spanSet"`
+}
+
+// SearchTraces runs a TraceQL search scoped to service and, if given,
+// operation (span name), via Tempo's /api/search endpoint.
+func (s *tempoSource) SearchTraces(ctx context.Context, service, operation string, limit int) ([]TraceSummary, error) {
+	query := fmt.Sprintf(`{resource.service.name="%s"}`, service)
+	if operation != "" {
+		query = fmt.Sprintf(`{resource.service.name="%s" && name="%s"}`, service, operation)
+	}
+
+	url := fmt.Sprintf("%s/api/search?q=%s", s.baseURL, query)
+	if limit > 0 {
+		url += fmt.Sprintf("&limit=%d", limit)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch traces: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Tempo API error: %s", string(body))
+	}
+
+	var searchResp tempoSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&searchResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	summaries := make([]TraceSummary, 0, len(searchResp.Traces))
+	for _, t := range searchResp.Traces {
+		summaries = append(summaries, TraceSummary{
+			TraceID:       t.TraceID,
+			SpanCount:     len(t.SpanSet.Spans),
+			OperationName: t.RootTraceName,
+			Duration:      durationMsToDuration(t.DurationMs),
+			Services:      []string{t.RootServiceName},
+		})
+	}
+
+	return summaries, nil
+}
+
+// tempoTagValuesResponse is the shape of Tempo's tag-values API.
+type tempoTagValuesResponse struct {
+	TagValues []struct {
+		Value string `json:"value"`
+	} `json:"tagValues"`
+}
+
+// ListServices lists the values of the service.name resource attribute via
+// Tempo's tag-values API.
+func (s *tempoSource) ListServices(ctx context.Context) ([]string, error) {
+	url := fmt.Sprintf("%s/api/v2/search/tag/resource.service.name/values", s.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch services: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Tempo API error: %s", string(body))
+	}
+
+	var tagResp tempoTagValuesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tagResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	services := make([]string, 0, len(tagResp.TagValues))
+	for _, v := range tagResp.TagValues {
+		services = append(services, v.Value)
+	}
+
+	return services, nil
+}
+
+// durationMsToDuration converts Tempo's millisecond duration field to a
+// time.Duration.
+func durationMsToDuration(ms int64) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
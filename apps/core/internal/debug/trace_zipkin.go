@@ -0,0 +1,193 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// zipkinSource queries a Zipkin v2 API (http://localhost:9411 by default).
+type zipkinSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newZipkinSource(baseURL string) *zipkinSource {
+	if baseURL == "" {
+		baseURL = "http://localhost:9411"
+	}
+	return &zipkinSource{baseURL: baseURL, client: defaultHTTPClient()}
+}
+
+// zipkinSpan is a single span as returned by Zipkin's v2 API.
+type zipkinSpan struct {
+	TraceID       string             `json:"traceId"`
+	ID            string             `json:"id"`
+	ParentID      string             `json:"parentId,omitempty"`
+	Name          string             `json:"name"`
+	Timestamp     int64              `json:"timestamp"`
+	Duration      int64              `json:"duration"`
+	LocalEndpoint zipkinEndpoint     `json:"localEndpoint"`
+	Tags          map[string]string  `json:"tags,omitempty"`
+	Annotations   []zipkinAnnotation `json:"annotations,omitempty"`
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type zipkinAnnotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+// GetTrace retrieves a trace by ID from Zipkin.
+func (s *zipkinSource) GetTrace(ctx context.Context, traceID string) (*TraceDetail, error) {
+	url := fmt.Sprintf("%s/api/v2/trace/%s", s.baseURL, traceID)
+
+	var spans []zipkinSpan
+	if err := s.doGet(ctx, url, &spans); err != nil {
+		return nil, fmt.Errorf("failed to fetch trace: %w", err)
+	}
+	if len(spans) == 0 {
+		return nil, fmt.Errorf("trace not found: %s", traceID)
+	}
+
+	return convertZipkinSpans(traceID, spans), nil
+}
+
+// SearchTraces finds recent traces by service and span name via Zipkin's
+// /api/v2/traces endpoint, then flattens each into a TraceSummary.
+func (s *zipkinSource) SearchTraces(ctx context.Context, service, operation string, limit int) ([]TraceSummary, error) {
+	url := fmt.Sprintf("%s/api/v2/traces?serviceName=%s", s.baseURL, service)
+	if operation != "" {
+		url += fmt.Sprintf("&spanName=%s", operation)
+	}
+	if limit > 0 {
+		url += fmt.Sprintf("&limit=%d", limit)
+	}
+
+	var traces [][]zipkinSpan
+	if err := s.doGet(ctx, url, &traces); err != nil {
+		return nil, fmt.Errorf("failed to fetch traces: %w", err)
+	}
+
+	summaries := make([]TraceSummary, 0, len(traces))
+	for _, spans := range traces {
+		if len(spans) == 0 {
+			continue
+		}
+		detail := convertZipkinSpans(spans[0].TraceID, spans)
+		summaries = append(summaries, TraceSummary{
+			TraceID:       detail.TraceID,
+			SpanCount:     detail.SpanCount,
+			OperationName: spans[0].Name,
+			StartTime:     detail.StartTime,
+			Duration:      time.Duration(spans[0].Duration) * time.Microsecond,
+			Services:      detail.Services,
+		})
+	}
+
+	return summaries, nil
+}
+
+// ListServices lists service names Zipkin has seen via /api/v2/services.
+func (s *zipkinSource) ListServices(ctx context.Context) ([]string, error) {
+	url := fmt.Sprintf("%s/api/v2/services", s.baseURL)
+	var services []string
+	if err := s.doGet(ctx, url, &services); err != nil {
+		return nil, fmt.Errorf("failed to fetch services: %w", err)
+	}
+	return services, nil
+}
+
+// doGet issues a GET request and decodes the JSON body into dest.
+func (s *zipkinSource) doGet(ctx context.Context, url string, dest interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Zipkin API error: %s", string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// convertZipkinSpans converts a flat list of Zipkin spans for one trace into
+// our TraceDetail format.
+func convertZipkinSpans(traceID string, spans []zipkinSpan) *TraceDetail {
+	detail := &TraceDetail{
+		TraceID:  traceID,
+		Spans:    make([]Span, 0, len(spans)),
+		Services: make([]string, 0),
+		Status:   "ok",
+		Errors:   make([]string, 0),
+	}
+
+	serviceSet := make(map[string]bool)
+	var minTime, maxTime time.Time
+
+	for _, zs := range spans {
+		span := Span{
+			SpanID:        zs.ID,
+			TraceID:       traceID,
+			ParentSpanID:  zs.ParentID,
+			OperationName: zs.Name,
+			ServiceName:   zs.LocalEndpoint.ServiceName,
+			StartTime:     time.UnixMicro(zs.Timestamp),
+			Duration:      time.Duration(zs.Duration) * time.Microsecond,
+			Tags:          zs.Tags,
+		}
+		span.EndTime = span.StartTime.Add(span.Duration)
+
+		if span.ServiceName != "" && !serviceSet[span.ServiceName] {
+			serviceSet[span.ServiceName] = true
+			detail.Services = append(detail.Services, span.ServiceName)
+		}
+
+		if errMsg, ok := zs.Tags["error"]; ok {
+			detail.Status = "error"
+			detail.Errors = append(detail.Errors, fmt.Sprintf("%s: %s", span.OperationName, errMsg))
+		}
+
+		for _, ann := range zs.Annotations {
+			span.Logs = append(span.Logs, SpanLog{
+				Timestamp: time.UnixMicro(ann.Timestamp),
+				Message:   ann.Value,
+			})
+		}
+
+		detail.Spans = append(detail.Spans, span)
+
+		if minTime.IsZero() || span.StartTime.Before(minTime) {
+			minTime = span.StartTime
+		}
+		if maxTime.IsZero() || span.EndTime.After(maxTime) {
+			maxTime = span.EndTime
+		}
+	}
+
+	if !minTime.IsZero() && !maxTime.IsZero() {
+		detail.Duration = maxTime.Sub(minTime).String()
+		detail.StartTime = minTime.Format(time.RFC3339)
+	}
+	detail.SpanCount = len(detail.Spans)
+
+	return detail
+}
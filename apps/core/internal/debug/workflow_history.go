@@ -0,0 +1,318 @@
+package debug
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"iterateswarm-core/internal/temporal"
+)
+
+// historyPageSize caps how many history events GetWorkflowExecutionHistory
+// returns per RPC; StreamWorkflowEvents issues one RPC per page.
+const historyPageSize = 100
+
+// WorkflowEvent is a typed view of a Temporal HistoryEvent, covering every
+// enumspb.EventType rather than just the activity lifecycle events. Type
+// holds the bare event name (e.g. "ActivityTaskScheduled", "TimerFired");
+// Attributes carries the event's own fields for types ExtractActivities
+// doesn't special-case.
+type WorkflowEvent struct {
+	EventID      int64                  `json:"event_id"`
+	EventType    string                 `json:"event_type"`
+	Timestamp    time.Time              `json:"timestamp"`
+	ActivityID   string                 `json:"activity_id,omitempty"`
+	ActivityType string                 `json:"activity_type,omitempty"`
+	Duration     time.Duration          `json:"duration,omitempty"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// GetWorkflowEvents returns one page of workflowID/runID's event history,
+// translated to WorkflowEvent, and a pageToken for the next page (empty once
+// the last page has been returned). runID may be empty to target the
+// workflow's current run. pageToken is the value previously returned by this
+// function; pass "" to fetch the first page.
+func GetWorkflowEvents(ctx context.Context, c *temporal.Client, workflowID, runID, pageToken string) ([]WorkflowEvent, string, error) {
+	nextToken, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid page token: %w", err)
+	}
+
+	resp, err := c.Client.WorkflowService().GetWorkflowExecutionHistory(ctx, &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace: c.Namespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      runID,
+		},
+		MaximumPageSize: historyPageSize,
+		NextPageToken:   nextToken,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get workflow history: %w", err)
+	}
+
+	events := make([]WorkflowEvent, 0, len(resp.GetHistory().GetEvents()))
+	for _, e := range resp.GetHistory().GetEvents() {
+		events = append(events, convertHistoryEvent(e))
+	}
+
+	return events, encodePageToken(resp.GetNextPageToken()), nil
+}
+
+// StreamWorkflowEvents streams workflowID/runID's entire event history, page
+// by page, onto the returned channel so callers don't have to hold a long
+// workflow's full history in memory at once. Both channels are closed when
+// the stream ends; a non-nil error on the error channel means the stream
+// stopped before the history was exhausted. Cancelling ctx stops the stream.
+func StreamWorkflowEvents(ctx context.Context, c *temporal.Client, workflowID, runID string) (<-chan WorkflowEvent, <-chan error) {
+	events := make(chan WorkflowEvent, historyPageSize)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		pageToken := ""
+		for {
+			page, next, err := GetWorkflowEvents(ctx, c, workflowID, runID, pageToken)
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			for _, e := range page {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+
+			if next == "" {
+				return
+			}
+			pageToken = next
+		}
+	}()
+
+	return events, errs
+}
+
+// ExtractActivities reconstructs ActivityDetail records from a workflow's
+// event stream by correlating each activity's scheduled/started/completed/
+// failed/timed-out/canceled events. Only ActivityTaskScheduled carries the
+// ActivityID directly; every later event instead references its scheduled
+// event by EventID, so that's how they're matched back to an activity.
+func ExtractActivities(events []WorkflowEvent) []ActivityDetail {
+	activities := make(map[string]*ActivityDetail)
+	scheduledEventActivity := make(map[int64]string)
+	var order []string
+
+	activityFor := func(e WorkflowEvent) (*ActivityDetail, bool) {
+		scheduledEventID, _ := e.Attributes["scheduled_event_id"].(int64)
+		id, ok := scheduledEventActivity[scheduledEventID]
+		if !ok {
+			return nil, false
+		}
+		a, ok := activities[id]
+		return a, ok
+	}
+
+	for _, e := range events {
+		switch e.EventType {
+		case "ActivityTaskScheduled":
+			activities[e.ActivityID] = &ActivityDetail{
+				ActivityID:    e.ActivityID,
+				ActivityType:  e.ActivityType,
+				ScheduledTime: e.Timestamp,
+				Status:        "scheduled",
+			}
+			scheduledEventActivity[e.EventID] = e.ActivityID
+			order = append(order, e.ActivityID)
+		case "ActivityTaskStarted":
+			if a, ok := activityFor(e); ok {
+				a.StartedTime = e.Timestamp
+				a.Status = "running"
+			}
+		case "ActivityTaskCompleted":
+			if a, ok := activityFor(e); ok {
+				a.CompletedTime = e.Timestamp
+				a.Status = "completed"
+				a.Duration = a.CompletedTime.Sub(a.ScheduledTime)
+			}
+		case "ActivityTaskFailed":
+			if a, ok := activityFor(e); ok {
+				a.FailedTime = e.Timestamp
+				a.Status = "failed"
+				a.Duration = a.FailedTime.Sub(a.ScheduledTime)
+			}
+		case "ActivityTaskTimedOut":
+			if a, ok := activityFor(e); ok {
+				a.FailedTime = e.Timestamp
+				a.Status = "timed_out"
+				a.Duration = a.FailedTime.Sub(a.ScheduledTime)
+			}
+		case "ActivityTaskCanceled":
+			if a, ok := activityFor(e); ok {
+				a.FailedTime = e.Timestamp
+				a.Status = "canceled"
+				a.Duration = a.FailedTime.Sub(a.ScheduledTime)
+			}
+		}
+	}
+
+	result := make([]ActivityDetail, 0, len(order))
+	for _, id := range order {
+		result = append(result, *activities[id])
+	}
+	return result
+}
+
+// convertHistoryEvent translates a single protobuf HistoryEvent into a
+// WorkflowEvent. Activity lifecycle events populate ActivityID/ActivityType
+// so ExtractActivities can correlate them; every other event type keeps its
+// own attributes in Attributes so nothing is silently dropped.
+func convertHistoryEvent(e *historypb.HistoryEvent) WorkflowEvent {
+	event := WorkflowEvent{
+		EventID:   e.GetEventId(),
+		EventType: eventTypeName(e.GetEventType()),
+		Timestamp: e.GetEventTime().AsTime(),
+	}
+
+	switch attrs := e.Attributes.(type) {
+	case *historypb.HistoryEvent_ActivityTaskScheduledEventAttributes:
+		event.ActivityID = attrs.ActivityTaskScheduledEventAttributes.GetActivityId()
+		event.ActivityType = attrs.ActivityTaskScheduledEventAttributes.GetActivityType().GetName()
+		event.Attributes = map[string]interface{}{
+			"task_queue":   attrs.ActivityTaskScheduledEventAttributes.GetTaskQueue().GetName(),
+			"max_attempts": attrs.ActivityTaskScheduledEventAttributes.GetRetryPolicy().GetMaximumAttempts(),
+		}
+	case *historypb.HistoryEvent_ActivityTaskStartedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"scheduled_event_id": attrs.ActivityTaskStartedEventAttributes.GetScheduledEventId(),
+			"attempt":            attrs.ActivityTaskStartedEventAttributes.GetAttempt(),
+		}
+	case *historypb.HistoryEvent_ActivityTaskCompletedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"scheduled_event_id": attrs.ActivityTaskCompletedEventAttributes.GetScheduledEventId(),
+		}
+	case *historypb.HistoryEvent_ActivityTaskFailedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"scheduled_event_id": attrs.ActivityTaskFailedEventAttributes.GetScheduledEventId(),
+			"failure":            attrs.ActivityTaskFailedEventAttributes.GetFailure().GetMessage(),
+		}
+	case *historypb.HistoryEvent_ActivityTaskTimedOutEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"scheduled_event_id": attrs.ActivityTaskTimedOutEventAttributes.GetScheduledEventId(),
+		}
+	case *historypb.HistoryEvent_ActivityTaskCanceledEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"scheduled_event_id": attrs.ActivityTaskCanceledEventAttributes.GetScheduledEventId(),
+		}
+	case *historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"workflow_type": attrs.WorkflowExecutionStartedEventAttributes.GetWorkflowType().GetName(),
+			"task_queue":    attrs.WorkflowExecutionStartedEventAttributes.GetTaskQueue().GetName(),
+		}
+	case *historypb.HistoryEvent_WorkflowExecutionCompletedEventAttributes:
+		event.Attributes = map[string]interface{}{}
+	case *historypb.HistoryEvent_WorkflowExecutionFailedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"failure": attrs.WorkflowExecutionFailedEventAttributes.GetFailure().GetMessage(),
+		}
+	case *historypb.HistoryEvent_WorkflowExecutionTimedOutEventAttributes:
+		event.Attributes = map[string]interface{}{}
+	case *historypb.HistoryEvent_WorkflowExecutionCanceledEventAttributes:
+		event.Attributes = map[string]interface{}{}
+	case *historypb.HistoryEvent_WorkflowExecutionTerminatedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"reason": attrs.WorkflowExecutionTerminatedEventAttributes.GetReason(),
+		}
+	case *historypb.HistoryEvent_WorkflowExecutionContinuedAsNewEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"new_run_id": attrs.WorkflowExecutionContinuedAsNewEventAttributes.GetNewExecutionRunId(),
+		}
+	case *historypb.HistoryEvent_TimerStartedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"timer_id": attrs.TimerStartedEventAttributes.GetTimerId(),
+		}
+	case *historypb.HistoryEvent_TimerFiredEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"timer_id": attrs.TimerFiredEventAttributes.GetTimerId(),
+		}
+	case *historypb.HistoryEvent_TimerCanceledEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"timer_id": attrs.TimerCanceledEventAttributes.GetTimerId(),
+		}
+	case *historypb.HistoryEvent_WorkflowExecutionSignaledEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"signal_name": attrs.WorkflowExecutionSignaledEventAttributes.GetSignalName(),
+		}
+	case *historypb.HistoryEvent_MarkerRecordedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"marker_name": attrs.MarkerRecordedEventAttributes.GetMarkerName(),
+		}
+	case *historypb.HistoryEvent_StartChildWorkflowExecutionInitiatedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"workflow_id":   attrs.StartChildWorkflowExecutionInitiatedEventAttributes.GetWorkflowId(),
+			"workflow_type": attrs.StartChildWorkflowExecutionInitiatedEventAttributes.GetWorkflowType().GetName(),
+		}
+	case *historypb.HistoryEvent_ChildWorkflowExecutionStartedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"workflow_id": attrs.ChildWorkflowExecutionStartedEventAttributes.GetWorkflowExecution().GetWorkflowId(),
+		}
+	case *historypb.HistoryEvent_ChildWorkflowExecutionCompletedEventAttributes:
+		event.Attributes = map[string]interface{}{}
+	case *historypb.HistoryEvent_ChildWorkflowExecutionFailedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"failure": attrs.ChildWorkflowExecutionFailedEventAttributes.GetFailure().GetMessage(),
+		}
+	case *historypb.HistoryEvent_WorkflowTaskScheduledEventAttributes:
+		event.Attributes = map[string]interface{}{}
+	case *historypb.HistoryEvent_WorkflowTaskStartedEventAttributes:
+		event.Attributes = map[string]interface{}{}
+	case *historypb.HistoryEvent_WorkflowTaskCompletedEventAttributes:
+		event.Attributes = map[string]interface{}{}
+	case *historypb.HistoryEvent_WorkflowTaskFailedEventAttributes:
+		event.Attributes = map[string]interface{}{
+			"failure": attrs.WorkflowTaskFailedEventAttributes.GetFailure().GetMessage(),
+		}
+	case *historypb.HistoryEvent_WorkflowTaskTimedOutEventAttributes:
+		event.Attributes = map[string]interface{}{}
+	}
+
+	return event
+}
+
+// eventTypeName strips enumspb's "EventType" prefix so the JSON taxonomy
+// matches Temporal's own event names (e.g. "ActivityTaskScheduled" rather
+// than "EVENT_TYPE_ACTIVITY_TASK_SCHEDULED").
+func eventTypeName(t enumspb.EventType) string {
+	name := t.String()
+	const prefix = "EventType"
+	if len(name) > len(prefix) && name[:len(prefix)] == prefix {
+		return name[len(prefix):]
+	}
+	return name
+}
+
+func encodePageToken(token []byte) string {
+	if len(token) == 0 {
+		return ""
+	}
+	return base64.URLEncoding.EncodeToString(token)
+}
+
+func decodePageToken(token string) ([]byte, error) {
+	if token == "" {
+		return nil, nil
+	}
+	return base64.URLEncoding.DecodeString(token)
+}
@@ -2,8 +2,12 @@ package debug
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
 	"iterateswarm-core/internal/temporal"
 )
 
@@ -20,6 +24,13 @@ type WorkflowSummary struct {
 	HistoryLength int64         `json:"history_length"`
 }
 
+// WorkflowPage is one page of ListWorkflows results with a cursor for the
+// next page, mirroring ListWorkflowExecutions' own pagination.
+type WorkflowPage struct {
+	Workflows     []WorkflowSummary `json:"workflows"`
+	NextPageToken string            `json:"next_page_token,omitempty"`
+}
+
 // WorkflowDetails represents detailed workflow information.
 type WorkflowDetails struct {
 	WorkflowSummary
@@ -43,18 +54,62 @@ type ActivityDetail struct {
 	Duration      time.Duration `json:"duration,omitempty"`
 }
 
-// ListWorkflows returns a list of workflows with optional filtering.
-// Note: Full listing requires workflowservice access. This returns an informational message.
-func ListWorkflows(ctx context.Context, c *temporal.Client, statusFilter, workflowType string, limit int) ([]WorkflowSummary, error) {
-	// The client SDK does not expose workflow listing.
-	// For production, use Temporal CLI: tctl workflow list
-	// or enable workflowservice access for admin operations.
-	return []WorkflowSummary{}, nil
+// ListWorkflows returns one page of workflows matching query, via
+// WorkflowServiceClient.ListWorkflowExecutions. pageToken is the value
+// previously returned in WorkflowPage.NextPageToken; pass "" for the first
+// page. limit caps the page size and defaults to 50 if <= 0.
+func ListWorkflows(ctx context.Context, c *temporal.Client, query WorkflowQuery, limit int, pageToken string) (*WorkflowPage, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	nextToken, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+
+	filterQuery, err := query.build()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow query: %w", err)
+	}
+
+	resp, err := c.Client.WorkflowService().ListWorkflowExecutions(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+		Namespace:     c.Namespace,
+		PageSize:      int32(limit),
+		NextPageToken: nextToken,
+		Query:         filterQuery,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow executions: %w", err)
+	}
+
+	workflows := make([]WorkflowSummary, 0, len(resp.GetExecutions()))
+	for _, info := range resp.GetExecutions() {
+		summary := WorkflowSummary{
+			WorkflowID:    info.GetExecution().GetWorkflowId(),
+			RunID:         info.GetExecution().GetRunId(),
+			WorkflowType:  info.GetType().GetName(),
+			Status:        workflowStatusName(info.GetStatus()),
+			StartTime:     info.GetStartTime().AsTime(),
+			TaskQueue:     info.GetTaskQueue(),
+			HistoryLength: info.GetHistoryLength(),
+		}
+		if info.GetCloseTime() != nil {
+			summary.CloseTime = info.GetCloseTime().AsTime()
+			summary.Duration = summary.CloseTime.Sub(summary.StartTime)
+		}
+		workflows = append(workflows, summary)
+	}
+
+	return &WorkflowPage{
+		Workflows:     workflows,
+		NextPageToken: encodePageToken(resp.GetNextPageToken()),
+	}, nil
 }
 
-// GetWorkflowDetails returns detailed information about a specific workflow.
+// GetWorkflowDetails returns detailed information about a specific workflow,
+// including its activities reconstructed from the full event history.
 func GetWorkflowDetails(ctx context.Context, c *temporal.Client, workflowID string) (*WorkflowDetails, error) {
-	// Get workflow run
 	run := c.Client.GetWorkflow(ctx, workflowID, "")
 
 	details := &WorkflowDetails{
@@ -65,10 +120,8 @@ func GetWorkflowDetails(ctx context.Context, c *temporal.Client, workflowID stri
 		Activities: make([]ActivityDetail, 0),
 	}
 
-	// Get workflow result (this also retrieves status info)
 	var result interface{}
-	err := run.Get(ctx, &result)
-	if err != nil {
+	if err := run.Get(ctx, &result); err != nil {
 		details.Error = err.Error()
 		details.Status = "failed"
 	} else {
@@ -76,65 +129,27 @@ func GetWorkflowDetails(ctx context.Context, c *temporal.Client, workflowID stri
 		details.Status = "completed"
 	}
 
-	return details, nil
-}
-
-// GetWorkflowEvents returns the event history for a workflow.
-// Note: Full history access requires workflowservice. This is a placeholder.
-func GetWorkflowEvents(ctx context.Context, c *temporal.Client, workflowID string) ([]WorkflowEvent, error) {
-	// The client SDK does not expose direct history access.
-	// For production, use: temporalctl workflow show <id>
-	return []WorkflowEvent{}, nil
-}
+	var events []WorkflowEvent
+	eventCh, errCh := StreamWorkflowEvents(ctx, c, run.GetID(), run.GetRunID())
+	for e := range eventCh {
+		events = append(events, e)
+	}
+	if err := <-errCh; err != nil {
+		return nil, fmt.Errorf("failed to load workflow history: %w", err)
+	}
+	details.Activities = ExtractActivities(events)
 
-// WorkflowEvent represents a workflow event.
-type WorkflowEvent struct {
-	EventID      int64           `json:"event_id"`
-	EventType    string          `json:"event_type"`
-	Timestamp    time.Time       `json:"timestamp"`
-	ActivityID   string          `json:"activity_id,omitempty"`
-	ActivityType string          `json:"activity_type,omitempty"`
-	Duration     time.Duration   `json:"duration,omitempty"`
+	return details, nil
 }
 
-// ExtractActivities extracts activity information from workflow events.
-func ExtractActivities(events []WorkflowEvent) []ActivityDetail {
-	activities := make(map[string]*ActivityDetail)
-
-	for _, e := range events {
-		switch e.EventType {
-		case "ActivityTaskScheduled":
-			activities[e.ActivityID] = &ActivityDetail{
-				ActivityID:    e.ActivityID,
-				ActivityType: e.ActivityType,
-				ScheduledTime: e.Timestamp,
-				Status:        "scheduled",
-			}
-		case "ActivityTaskStarted":
-			if a, ok := activities[e.ActivityID]; ok {
-				a.StartedTime = e.Timestamp
-				a.Status = "running"
-			}
-		case "ActivityTaskCompleted":
-			if a, ok := activities[e.ActivityID]; ok {
-				a.CompletedTime = e.Timestamp
-				a.Status = "completed"
-				a.Duration = a.CompletedTime.Sub(a.ScheduledTime)
-			}
-		case "ActivityTaskFailed":
-			if a, ok := activities[e.ActivityID]; ok {
-				a.FailedTime = e.Timestamp
-				a.Status = "failed"
-				a.Duration = a.FailedTime.Sub(a.ScheduledTime)
-			}
-		}
+// workflowStatusName strips enumspb's "WorkflowExecutionStatus" prefix so
+// JSON consumers see e.g. "Completed" rather than
+// "WORKFLOW_EXECUTION_STATUS_COMPLETED".
+func workflowStatusName(s enumspb.WorkflowExecutionStatus) string {
+	name := s.String()
+	const prefix = "WorkflowExecutionStatus"
+	if len(name) > len(prefix) && name[:len(prefix)] == prefix {
+		return name[len(prefix):]
 	}
-
-	// Convert map to slice
-	result := make([]ActivityDetail, 0, len(activities))
-	for _, a := range activities {
-		result = append(result, *a)
-	}
-
-	return result
+	return name
 }
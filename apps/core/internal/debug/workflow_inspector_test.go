@@ -0,0 +1,171 @@
+package debug
+
+import (
+	"context"
+	"testing"
+
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"iterateswarm-core/internal/temporal"
+)
+
+// mockWorkflowServiceClient implements workflowservice.WorkflowServiceClient
+// by embedding the (nil) interface and overriding only the RPCs exercised
+// here; any other call would panic on the nil embed, which is fine since
+// these tests never trigger one.
+type mockWorkflowServiceClient struct {
+	workflowservice.WorkflowServiceClient
+	listFunc    func(ctx context.Context, in *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error)
+	historyFunc func(ctx context.Context, in *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error)
+}
+
+func (m *mockWorkflowServiceClient) ListWorkflowExecutions(ctx context.Context, in *workflowservice.ListWorkflowExecutionsRequest, opts ...grpc.CallOption) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	return m.listFunc(ctx, in)
+}
+
+func (m *mockWorkflowServiceClient) GetWorkflowExecutionHistory(ctx context.Context, in *workflowservice.GetWorkflowExecutionHistoryRequest, opts ...grpc.CallOption) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+	return m.historyFunc(ctx, in)
+}
+
+// mockClient implements client.Client by embedding the (nil) interface and
+// overriding WorkflowService to return a mockWorkflowServiceClient.
+type mockClient struct {
+	client.Client
+	ws workflowservice.WorkflowServiceClient
+}
+
+func (m *mockClient) WorkflowService() workflowservice.WorkflowServiceClient {
+	return m.ws
+}
+
+func newTestClient(ws *mockWorkflowServiceClient) *temporal.Client {
+	return &temporal.Client{Client: &mockClient{ws: ws}, Namespace: "test-namespace"}
+}
+
+func TestListWorkflows(t *testing.T) {
+	ws := &mockWorkflowServiceClient{
+		listFunc: func(ctx context.Context, in *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+			if in.Namespace != "test-namespace" {
+				t.Errorf("expected namespace 'test-namespace', got %q", in.Namespace)
+			}
+			if in.Query != `ExecutionStatus = "Running"` {
+				t.Errorf("unexpected query: %q", in.Query)
+			}
+			return &workflowservice.ListWorkflowExecutionsResponse{
+				Executions: []*workflowpb.WorkflowExecutionInfo{
+					{
+						Execution: &commonpb.WorkflowExecution{WorkflowId: "wf-1", RunId: "run-1"},
+						Type:      &commonpb.WorkflowType{Name: "FeedbackWorkflow"},
+						Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+						StartTime: timestamppb.Now(),
+					},
+				},
+				NextPageToken: []byte("cursor-1"),
+			}, nil
+		},
+	}
+
+	page, err := ListWorkflows(context.Background(), newTestClient(ws), WorkflowQuery{Status: "Running"}, 10, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Workflows) != 1 {
+		t.Fatalf("expected 1 workflow, got %d", len(page.Workflows))
+	}
+	if page.Workflows[0].WorkflowID != "wf-1" {
+		t.Errorf("expected workflow id 'wf-1', got %q", page.Workflows[0].WorkflowID)
+	}
+	if page.Workflows[0].Status != "Running" {
+		t.Errorf("expected status 'Running', got %q", page.Workflows[0].Status)
+	}
+	if page.NextPageToken == "" {
+		t.Error("expected a non-empty next page token")
+	}
+}
+
+func TestGetWorkflowEvents(t *testing.T) {
+	ws := &mockWorkflowServiceClient{
+		historyFunc: func(ctx context.Context, in *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+			if in.Execution.GetWorkflowId() != "wf-1" {
+				t.Errorf("expected workflow id 'wf-1', got %q", in.Execution.GetWorkflowId())
+			}
+			return &workflowservice.GetWorkflowExecutionHistoryResponse{
+				History: &historypb.History{
+					Events: []*historypb.HistoryEvent{
+						{
+							EventId:   1,
+							EventTime: timestamppb.Now(),
+							EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+							Attributes: &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+								ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+									ActivityId:   "act-1",
+									ActivityType: &commonpb.ActivityType{Name: "SendNotification"},
+								},
+							},
+						},
+					},
+				},
+			}, nil
+		},
+	}
+
+	events, nextPageToken, err := GetWorkflowEvents(context.Background(), newTestClient(ws), "wf-1", "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nextPageToken != "" {
+		t.Errorf("expected empty next page token on last page, got %q", nextPageToken)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].EventType != "ActivityTaskScheduled" {
+		t.Errorf("expected event type 'ActivityTaskScheduled', got %q", events[0].EventType)
+	}
+	if events[0].ActivityID != "act-1" {
+		t.Errorf("expected activity id 'act-1', got %q", events[0].ActivityID)
+	}
+
+	activities := ExtractActivities(events)
+	if len(activities) != 1 || activities[0].Status != "scheduled" {
+		t.Fatalf("expected 1 scheduled activity, got %+v", activities)
+	}
+}
+
+func TestWorkflowQueryBuild(t *testing.T) {
+	q := WorkflowQuery{Status: "Running", WorkflowType: "FeedbackWorkflow"}
+	got, err := q.build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `ExecutionStatus = "Running" and WorkflowType = "FeedbackWorkflow"`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWorkflowQueryBuildRejectsInvalidStatus(t *testing.T) {
+	q := WorkflowQuery{Status: `Running" or "1"="1`}
+	if _, err := q.build(); err == nil {
+		t.Error("expected an error for an unrecognized status, got nil")
+	}
+}
+
+func TestWorkflowQueryBuildEscapesWorkflowType(t *testing.T) {
+	q := WorkflowQuery{WorkflowType: `Feedback" or "1"="1`}
+	got, err := q.build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `WorkflowType = "Feedback\" or \"1\"=\"1"`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+// validWorkflowStatuses is the allowlist of ExecutionStatus values build
+// accepts for WorkflowQuery.Status, keyed by the friendly name
+// workflowStatusName strips enumspb's WorkflowExecutionStatus down to (e.g.
+// "Running", not "WORKFLOW_EXECUTION_STATUS_RUNNING").
+var validWorkflowStatuses = func() map[string]bool {
+	statuses := make(map[string]bool, len(enumspb.WorkflowExecutionStatus_name))
+	for v := range enumspb.WorkflowExecutionStatus_name {
+		statuses[workflowStatusName(enumspb.WorkflowExecutionStatus(v))] = true
+	}
+	return statuses
+}()
+
+// WorkflowQuery builds the SQL-like List Filter query string Temporal's
+// Visibility API (ListWorkflowExecutions) expects, from structured fields,
+// so callers filter workflows without hand-writing Visibility query syntax.
+type WorkflowQuery struct {
+	Status        string
+	WorkflowType  string
+	StartedAfter  time.Time
+	StartedBefore time.Time
+}
+
+// build renders q as a Visibility List Filter query. An empty WorkflowQuery
+// renders to "", which Temporal treats as "list everything". Status and
+// WorkflowType come straight from query params, so Status is checked
+// against validWorkflowStatuses and WorkflowType has its quotes escaped
+// before either is interpolated into the query string — otherwise a value
+// like `x" or "1"="1` would break out of its clause.
+func (q WorkflowQuery) build() (string, error) {
+	var clauses []string
+
+	if q.Status != "" {
+		if !validWorkflowStatuses[q.Status] {
+			return "", fmt.Errorf("invalid status %q", q.Status)
+		}
+		clauses = append(clauses, `ExecutionStatus = "`+q.Status+`"`)
+	}
+	if q.WorkflowType != "" {
+		clauses = append(clauses, `WorkflowType = "`+escapeFilterValue(q.WorkflowType)+`"`)
+	}
+	if !q.StartedAfter.IsZero() {
+		clauses = append(clauses, `StartTime > "`+q.StartedAfter.UTC().Format(time.RFC3339)+`"`)
+	}
+	if !q.StartedBefore.IsZero() {
+		clauses = append(clauses, `StartTime < "`+q.StartedBefore.UTC().Format(time.RFC3339)+`"`)
+	}
+
+	return strings.Join(clauses, " and "), nil
+}
+
+// escapeFilterValue escapes backslashes and double quotes so s can't break
+// out of the double-quoted string literal it's interpolated into.
+func escapeFilterValue(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
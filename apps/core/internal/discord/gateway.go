@@ -0,0 +1,237 @@
+// Package discord listens for the Discord interactions SendDiscordApproval's
+// buttons produce and turns them into Temporal signals.
+package discord
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"iterateswarm-core/internal/logging"
+	"iterateswarm-core/internal/temporal"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ApprovalSignal is the payload sent to FeedbackWorkflow when a reviewer
+// clicks Approve/Reject on the embed SendDiscordApproval posted.
+type ApprovalSignal struct {
+	Approved bool
+	UserID   string
+	Reason   string
+}
+
+// ApprovalSignalName is the Temporal signal name FeedbackWorkflow should
+// listen on to receive ApprovalSignal values.
+const ApprovalSignalName = "approval-signal"
+
+// Gateway is a long-lived Discord session that subscribes to button/modal
+// interactions and correlates them back to the Temporal workflow that
+// posted them.
+type Gateway struct {
+	session        *discordgo.Session
+	temporalClient *temporal.Client
+	allowedRoles   map[string]bool
+	logger         *logging.Logger
+}
+
+// NewGateway creates a Gateway authenticated with token. allowedRoles, if
+// non-empty, restricts who may approve/reject to members holding one of
+// those Discord role IDs; an empty slice allows anyone in the channel.
+func NewGateway(token string, temporalClient *temporal.Client, allowedRoles []string) (*Gateway, error) {
+	session, err := discordgo.New("Bot " + token)
+	if err != nil {
+		return nil, fmt.Errorf("discord gateway: failed to create session: %w", err)
+	}
+
+	roles := make(map[string]bool, len(allowedRoles))
+	for _, r := range allowedRoles {
+		roles[r] = true
+	}
+
+	g := &Gateway{
+		session:        session,
+		temporalClient: temporalClient,
+		allowedRoles:   roles,
+		logger:         logging.NewLogger("discord-gateway"),
+	}
+
+	session.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMessages
+	session.AddHandler(g.handleInteraction)
+
+	return g, nil
+}
+
+// Start opens the Discord session so InteractionCreate events begin
+// flowing in. Call Close when the worker shuts down.
+func (g *Gateway) Start() error {
+	if err := g.session.Open(); err != nil {
+		return fmt.Errorf("discord gateway: failed to open session: %w", err)
+	}
+	g.logger.Info("discord gateway connected")
+	return nil
+}
+
+// Close tears down the Discord session.
+func (g *Gateway) Close() error {
+	return g.session.Close()
+}
+
+// handleInteraction dispatches Approve/Reject button clicks and the
+// reject-reason modal submitted in response to them.
+func (g *Gateway) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	switch i.Type {
+	case discordgo.InteractionMessageComponent:
+		g.handleButton(s, i)
+	case discordgo.InteractionModalSubmit:
+		g.handleRejectModal(s, i)
+	}
+}
+
+func (g *Gateway) handleButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	customID := i.MessageComponentData().CustomID
+	action, workflowID, ok := splitCustomID(customID)
+	if !ok {
+		return
+	}
+
+	member := i.Member
+	if member == nil {
+		g.respondEphemeral(s, i, "This action can only be used inside a server.")
+		return
+	}
+
+	if !g.isAllowed(member) {
+		g.logger.Warn("rejected interaction from disallowed role",
+			"user_id", member.User.ID,
+			"workflow_id", workflowID,
+		)
+		g.respondEphemeral(s, i, "You do not have permission to approve or reject this issue.")
+		return
+	}
+
+	switch action {
+	case "approve":
+		g.signalAndAck(s, i, workflowID, ApprovalSignal{Approved: true, UserID: member.User.ID})
+	case "reject":
+		g.openRejectModal(s, i, workflowID)
+	}
+}
+
+// openRejectModal prompts the reviewer for a reject reason before the
+// signal is sent, so FeedbackWorkflow can record why an issue was turned down.
+func (g *Gateway) openRejectModal(s *discordgo.Session, i *discordgo.InteractionCreate, workflowID string) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: "reject_" + workflowID,
+			Title:    "Reject issue",
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.TextInput{
+							CustomID:    "reason",
+							Label:       "Reason (optional)",
+							Style:       discordgo.TextInputParagraph,
+							Required:    false,
+							MaxLength:   500,
+							Placeholder: "Why is this being rejected?",
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		g.logger.Error("failed to open reject modal", err, "workflow_id", workflowID)
+	}
+}
+
+func (g *Gateway) handleRejectModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	data := i.ModalSubmitData()
+	_, workflowID, ok := splitCustomID(data.CustomID)
+	if !ok {
+		return
+	}
+
+	reason := ""
+	if row, ok := data.Components[0].(*discordgo.ActionsRow); ok && len(row.Components) > 0 {
+		if input, ok := row.Components[0].(*discordgo.TextInput); ok {
+			reason = input.Value
+		}
+	}
+
+	g.signalAndAck(s, i, workflowID, ApprovalSignal{
+		Approved: false,
+		UserID:   i.Member.User.ID,
+		Reason:   reason,
+	})
+}
+
+// signalAndAck signals FeedbackWorkflow and edits the original approval
+// message to show the outcome, disabling the buttons.
+func (g *Gateway) signalAndAck(s *discordgo.Session, i *discordgo.InteractionCreate, workflowID string, signal ApprovalSignal) {
+	ctx := context.Background()
+	if err := g.temporalClient.Client.SignalWorkflow(ctx, workflowID, "", ApprovalSignalName, signal); err != nil {
+		g.logger.Error("failed to signal workflow", err, "workflow_id", workflowID)
+		g.respondEphemeral(s, i, "Failed to record your decision, please try again.")
+		return
+	}
+
+	content := fmt.Sprintf("Rejected by <@%s>", signal.UserID)
+	if signal.Approved {
+		content = fmt.Sprintf("Approved by <@%s>", signal.UserID)
+	}
+	if signal.Reason != "" {
+		content += fmt.Sprintf(" — %s", signal.Reason)
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		g.logger.Error("failed to ack interaction", err, "workflow_id", workflowID)
+	}
+
+	g.logger.Info("approval decision recorded",
+		"workflow_id", workflowID,
+		"approved", signal.Approved,
+		"user_id", signal.UserID,
+	)
+}
+
+func (g *Gateway) respondEphemeral(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Flags:   discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+func (g *Gateway) isAllowed(member *discordgo.Member) bool {
+	if len(g.allowedRoles) == 0 {
+		return true
+	}
+	for _, role := range member.Roles {
+		if g.allowedRoles[role] {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCustomID parses a "<action>_<workflowID>" custom ID as emitted by
+// SendDiscordApproval into its action and workflow ID parts.
+func splitCustomID(customID string) (action, workflowID string, ok bool) {
+	parts := strings.SplitN(customID, "_", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pb "github.com/Aparnap2/iterate_swarm/gen/go/ai/v1"
+)
+
+// FeedbackAnalyzer produces an AnalyzeFeedbackResponse for one piece of
+// feedback. AgentServer delegates every RPC to one of these instead of
+// implementing analysis itself, so the same server skeleton can be pointed
+// at either a real model-backed analyzer or, for local development and
+// integration tests, NaiveAnalyzer below.
+type FeedbackAnalyzer interface {
+	Analyze(ctx context.Context, text, source, userID string) (*pb.AnalyzeFeedbackResponse, error)
+}
+
+// NaiveAnalyzer is a dependency-free, keyword-based FeedbackAnalyzer. It
+// exists so AgentServer can run in tests and local development without the
+// real Python AI service reachable; it is not a production substitute for
+// it, and AgentServer treats it only as the default when no analyzer is
+// supplied.
+type NaiveAnalyzer struct{}
+
+// Analyze classifies text by keyword matching alone.
+func (NaiveAnalyzer) Analyze(ctx context.Context, text, source, userID string) (*pb.AnalyzeFeedbackResponse, error) {
+	lower := strings.ToLower(text)
+
+	issueType := pb.IssueType_ISSUE_TYPE_QUESTION
+	switch {
+	case strings.Contains(lower, "bug") || strings.Contains(lower, "crash") || strings.Contains(lower, "error"):
+		issueType = pb.IssueType_ISSUE_TYPE_BUG
+	case strings.Contains(lower, "feature") || strings.Contains(lower, "please add") || strings.Contains(lower, "would be nice"):
+		issueType = pb.IssueType_ISSUE_TYPE_FEATURE
+	}
+
+	severity := pb.Severity_SEVERITY_LOW
+	switch {
+	case strings.Contains(lower, "critical") || strings.Contains(lower, "urgent"):
+		severity = pb.Severity_SEVERITY_CRITICAL
+	case strings.Contains(lower, "broken") || strings.Contains(lower, "important"):
+		severity = pb.Severity_SEVERITY_HIGH
+	case issueType == pb.IssueType_ISSUE_TYPE_BUG:
+		severity = pb.Severity_SEVERITY_MEDIUM
+	}
+
+	return &pb.AnalyzeFeedbackResponse{
+		IsDuplicate: false,
+		Reasoning:   fmt.Sprintf("naive analyzer classified this %s-sourced feedback as %s/%s by keyword match", source, issueType, severity),
+		Spec: &pb.IssueSpec{
+			Title:       truncateTitle(text),
+			Severity:    severity,
+			Type:        issueType,
+			Description: text,
+			Labels:      []string{"needs-triage"},
+		},
+	}, nil
+}
+
+func truncateTitle(text string) string {
+	const maxTitleLen = 80
+	text = strings.TrimSpace(text)
+	if len(text) <= maxTitleLen {
+		return text
+	}
+	return text[:maxTitleLen-3] + "..."
+}
@@ -0,0 +1,197 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchConfig tunes how StreamBatcher coalesces requests into stream frames.
+type BatchConfig struct {
+	// Window is how long the batcher waits after the first pending item
+	// before flushing, even if MaxBatchSize hasn't been reached.
+	Window time.Duration
+	// MaxBatchSize flushes the batch early once this many items are pending.
+	MaxBatchSize int
+	// MaxConcurrentStreams bounds how many AnalyzeStream sessions the
+	// batcher keeps open at once, providing backpressure on bursts.
+	MaxConcurrentStreams int
+}
+
+// DefaultBatchConfig coalesces by a 200ms window or 32 items, whichever
+// comes first, over at most 4 concurrent streams.
+func DefaultBatchConfig() BatchConfig {
+	return BatchConfig{
+		Window:               200 * time.Millisecond,
+		MaxBatchSize:         32,
+		MaxConcurrentStreams: 4,
+	}
+}
+
+// pendingRequest is a StreamRequest awaiting a flush, with a channel its
+// caller is blocked receiving the eventual response on.
+type pendingRequest struct {
+	req    StreamRequest
+	result chan streamResult
+}
+
+type streamResult struct {
+	resp *StreamResponse
+	err  error
+}
+
+// StreamBatcher coalesces AnalyzeFeedback calls arriving within a window (or
+// N items) into a single AnalyzeFeedbackStream frame, so a burst of Discord
+// or Kafka events costs one streamed RPC instead of one unary RPC each.
+// Requests are distributed over MaxConcurrentStreams sessions, and each
+// frame is ordered round-robin over UserID (one request per user per round;
+// users carry no weights) so one noisy tenant can't starve others.
+type StreamBatcher struct {
+	client *Client
+	config BatchConfig
+
+	mu       sync.Mutex
+	pending  []pendingRequest
+	flushAt  *time.Timer
+	inflight int
+	cond     *sync.Cond
+}
+
+// NewStreamBatcher creates a batcher over client using config.
+func NewStreamBatcher(client *Client, config BatchConfig) *StreamBatcher {
+	b := &StreamBatcher{client: client, config: config}
+	b.cond = sync.NewCond(&b.mu)
+	return b
+}
+
+// Submit enqueues req and blocks until its matching response arrives (or
+// ctx is cancelled).
+func (b *StreamBatcher) Submit(ctx context.Context, req StreamRequest) (*StreamResponse, error) {
+	pending := pendingRequest{req: req, result: make(chan streamResult, 1)}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, pending)
+	shouldFlushNow := len(b.pending) >= b.config.MaxBatchSize
+	if b.flushAt == nil && !shouldFlushNow {
+		b.flushAt = time.AfterFunc(b.config.Window, b.flush)
+	}
+	b.mu.Unlock()
+
+	if shouldFlushNow {
+		b.flush()
+	}
+
+	select {
+	case res := <-pending.result:
+		return res.resp, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// flush takes whatever is pending, waits for a free stream slot (providing
+// backpressure up to MaxConcurrentStreams), and sends it as one stream frame.
+func (b *StreamBatcher) flush() {
+	b.mu.Lock()
+	if b.flushAt != nil {
+		b.flushAt.Stop()
+		b.flushAt = nil
+	}
+	batch := b.pending
+	b.pending = nil
+	for b.inflight >= b.config.MaxConcurrentStreams {
+		b.cond.Wait()
+	}
+	b.inflight++
+	b.mu.Unlock()
+
+	go b.send(batch)
+}
+
+func (b *StreamBatcher) send(batch []pendingRequest) {
+	defer func() {
+		b.mu.Lock()
+		b.inflight--
+		b.cond.Signal()
+		b.mu.Unlock()
+	}()
+
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	sender, receiver, err := b.client.AnalyzeStream(ctx)
+	if err != nil {
+		b.failAll(batch, err)
+		return
+	}
+
+	byRequestID := make(map[string]pendingRequest, len(batch))
+	for _, p := range fairOrder(batch) {
+		byRequestID[p.req.RequestID] = p
+		if err := sender.Send(p.req); err != nil {
+			p.result <- streamResult{err: fmt.Errorf("failed to send batched request: %w", err)}
+			delete(byRequestID, p.req.RequestID)
+		}
+	}
+	_ = sender.CloseSend()
+
+	for len(byRequestID) > 0 {
+		resp, err := receiver.Recv()
+		if err != nil {
+			b.failRemaining(byRequestID, err)
+			return
+		}
+		if p, ok := byRequestID[resp.RequestID]; ok {
+			respCopy := resp
+			p.result <- streamResult{resp: &respCopy}
+			delete(byRequestID, resp.RequestID)
+		}
+	}
+}
+
+func (b *StreamBatcher) failAll(batch []pendingRequest, err error) {
+	for _, p := range batch {
+		p.result <- streamResult{err: err}
+	}
+}
+
+func (b *StreamBatcher) failRemaining(remaining map[string]pendingRequest, err error) {
+	for _, p := range remaining {
+		p.result <- streamResult{err: err}
+	}
+}
+
+// fairOrder interleaves batch by UserID (plain round-robin: one request per
+// user per round, every user weighted equally) so a single
+// tenant's burst of requests doesn't monopolize the front of the frame.
+func fairOrder(batch []pendingRequest) []pendingRequest {
+	byUser := make(map[string][]pendingRequest)
+	var users []string
+	for _, p := range batch {
+		if _, seen := byUser[p.req.UserID]; !seen {
+			users = append(users, p.req.UserID)
+		}
+		byUser[p.req.UserID] = append(byUser[p.req.UserID], p)
+	}
+
+	ordered := make([]pendingRequest, 0, len(batch))
+	for {
+		progressed := false
+		for _, user := range users {
+			queue := byUser[user]
+			if len(queue) == 0 {
+				continue
+			}
+			ordered = append(ordered, queue[0])
+			byUser[user] = queue[1:]
+			progressed = true
+		}
+		if !progressed {
+			break
+		}
+	}
+	return ordered
+}
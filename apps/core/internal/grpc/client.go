@@ -2,7 +2,10 @@ package grpc
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"time"
+
+	"iterateswarm-core/internal/logging"
 
 	pb "github.com/Aparnap2/iterate_swarm/gen/go/ai/v1"
 	"google.golang.org/grpc"
@@ -13,34 +16,57 @@ import (
 type Client struct {
 	conn   *grpc.ClientConn
 	client pb.AgentServiceClient
+	logger *logging.Logger
+	health *healthChecker
 }
 
-// NewClient creates a new gRPC client connected to the Python AI service.
+// NewClient creates a new gRPC client connected to the Python AI service
+// over an insecure, round-robin load-balanced connection. Use
+// NewClientWithConfig for mTLS or a non-default load-balancing policy.
 func NewClient(addr string) (*Client, error) {
-	log.Printf("Connecting to gRPC server at %s", addr)
+	return NewClientWithConfig(DefaultClientConfig(addr))
+}
 
-	conn, err := grpc.NewClient(
-		addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
-	if err != nil {
-		return nil, err
+// NewClientWithoutBlock creates a new gRPC client without blocking; kept as
+// a distinct name for callers that want to make the non-blocking intent
+// explicit, grpc.NewClient itself never blocks.
+func NewClientWithoutBlock(addr string) (*Client, error) {
+	return NewClientWithConfig(DefaultClientConfig(addr))
+}
+
+// NewClientWithConfig creates a gRPC client using cfg's transport
+// credentials, load-balancing policy, retry/timeout interceptors, and
+// health checking.
+func NewClientWithConfig(cfg ClientConfig) (*Client, error) {
+	logger := logging.NewLogger("grpc-client")
+	logger.Info("connecting to gRPC server", "addr", cfg.Addr, "tls", cfg.TLS != nil)
+
+	transportCreds := insecure.NewCredentials()
+	if cfg.TLS != nil {
+		creds, err := cfg.TLS.Credentials()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build TLS credentials: %w", err)
+		}
+		transportCreds = creds
 	}
 
-	return &Client{
-		conn:   conn,
-		client: pb.NewAgentServiceClient(conn),
-	}, nil
-}
+	lbPolicy := cfg.LoadBalancingPolicy
+	if lbPolicy == "" {
+		lbPolicy = "round_robin"
+	}
 
-// NewClientWithoutBlock creates a new gRPC client without blocking.
-func NewClientWithoutBlock(addr string) (*Client, error) {
-	log.Printf("Connecting to gRPC server at %s (non-blocking)", addr)
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
+		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, lbPolicy)),
+		grpc.WithChainUnaryInterceptor(
+			metricsUnaryInterceptor(),
+			timeoutUnaryInterceptor(cfg.CallTimeout),
+			retryUnaryInterceptor(logger, cfg.MaxRetries),
+			loggingUnaryInterceptor(logger),
+		),
+	}
 
-	conn, err := grpc.NewClient(
-		addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	conn, err := grpc.NewClient(cfg.Addr, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -48,9 +74,29 @@ func NewClientWithoutBlock(addr string) (*Client, error) {
 	return &Client{
 		conn:   conn,
 		client: pb.NewAgentServiceClient(conn),
+		logger: logger,
+		health: startHealthChecker(conn, logger),
 	}, nil
 }
 
+// loggingUnaryInterceptor logs every unary RPC the client makes, replacing
+// the ad-hoc log.Printf calls that used to live inline in each method.
+func loggingUnaryInterceptor(logger *logging.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		duration := time.Since(start)
+
+		if err != nil {
+			logger.Error("rpc failed", err, "method", method, "duration_ms", duration.Milliseconds())
+		} else {
+			logger.Debug("rpc completed", "method", method, "duration_ms", duration.Milliseconds())
+		}
+
+		return err
+	}
+}
+
 // AnalyzeFeedback sends feedback to the Python AI service for analysis.
 func (c *Client) AnalyzeFeedback(ctx context.Context, text, source, userID string) (*pb.AnalyzeFeedbackResponse, error) {
 	req := &pb.AnalyzeFeedbackRequest{
@@ -59,19 +105,15 @@ func (c *Client) AnalyzeFeedback(ctx context.Context, text, source, userID strin
 		UserId: userID,
 	}
 
-	log.Printf("Sending feedback to AI service: text=%s, source=%s, user=%s", text, source, userID)
-
 	resp, err := c.client.AnalyzeFeedback(ctx, req)
 	if err != nil {
-		log.Printf("AI service error: %v", err)
 		return nil, err
 	}
 
-	log.Printf(
-		"AI analysis complete: is_duplicate=%v, type=%v, severity=%v",
-		resp.IsDuplicate,
-		resp.Spec.Type,
-		resp.Spec.Severity,
+	c.logger.Info("AI analysis complete",
+		"is_duplicate", resp.IsDuplicate,
+		"type", resp.Spec.Type,
+		"severity", resp.Spec.Severity,
 	)
 
 	return resp, nil
@@ -79,7 +121,10 @@ func (c *Client) AnalyzeFeedback(ctx context.Context, text, source, userID strin
 
 // Close closes the gRPC connection.
 func (c *Client) Close() error {
-	log.Println("Closing gRPC connection")
+	c.logger.Info("closing gRPC connection")
+	if c.health != nil {
+		c.health.Stop()
+	}
 	return c.conn.Close()
 }
 
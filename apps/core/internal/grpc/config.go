@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSConfig holds the material needed for mutual TLS against the Python AI
+// service: a CA bundle to verify the server, and optionally a client
+// cert/key pair for the server to verify us.
+type TLSConfig struct {
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+}
+
+// Credentials builds the transport credentials described by c.
+func (c *TLSConfig) Credentials() (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{ServerName: c.ServerName}
+
+	if c.CAFile != "" {
+		caCert, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle %s: %w", c.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse CA bundle %s", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.CertFile != "" && c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client cert/key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// ClientConfig configures how Client connects to the Python AI service.
+type ClientConfig struct {
+	// Addr is the target address. Prefix with "dns:///" or "xds:///" to
+	// pick the corresponding resolver; a bare "host:port" uses the default
+	// passthrough resolver against a single instance.
+	Addr string
+	// TLS enables mTLS when non-nil; a nil TLS uses an insecure connection.
+	TLS *TLSConfig
+	// LoadBalancingPolicy is a gRPC service config policy name, e.g.
+	// "round_robin" (the default) or "pick_first".
+	LoadBalancingPolicy string
+	// MaxRetries is the number of retry attempts the retry interceptor
+	// makes on UNAVAILABLE/DEADLINE_EXCEEDED before giving up.
+	MaxRetries int
+	// CallTimeout bounds every unary call if non-zero. Streaming calls
+	// (AnalyzeStream) are not bounded by it.
+	CallTimeout time.Duration
+}
+
+// DefaultClientConfig returns an insecure, round-robin config with the
+// retry/timeout defaults used by NewClient.
+func DefaultClientConfig(addr string) ClientConfig {
+	return ClientConfig{
+		Addr:                addr,
+		LoadBalancingPolicy: "round_robin",
+		MaxRetries:          3,
+		CallTimeout:         30 * time.Second,
+	}
+}
@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/Aparnap2/iterate_swarm/gen/go/ai/v1"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// NewGatewayMux returns an http.Handler (runtime.ServeMux implements one)
+// that translates REST/JSON requests into calls against the AgentService
+// gRPC server listening on grpcAddr, so the same AnalyzeFeedback/BatchAnalyze
+// surface NewGRPCServer exposes is also reachable over plain HTTP for
+// callers that can't speak gRPC.
+func NewGatewayMux(ctx context.Context, grpcAddr string) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux()
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := pb.RegisterAgentServiceHandlerFromEndpoint(ctx, mux, grpcAddr, opts); err != nil {
+		return nil, fmt.Errorf("failed to register AgentService gateway handler: %w", err)
+	}
+
+	return mux, nil
+}
@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"iterateswarm-core/internal/logging"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthChecker polls the Python AI service's grpc.health.v1 endpoint on an
+// interval and exposes the last known serving state for the worker's
+// readiness probe.
+type healthChecker struct {
+	client   healthpb.HealthClient
+	logger   *logging.Logger
+	serving  atomic.Bool
+	lastErr  atomic.Value // string
+	cancel   context.CancelFunc
+}
+
+func startHealthChecker(conn *grpc.ClientConn, logger *logging.Logger) *healthChecker {
+	ctx, cancel := context.WithCancel(context.Background())
+	hc := &healthChecker{
+		client: healthpb.NewHealthClient(conn),
+		logger: logger,
+		cancel: cancel,
+	}
+	hc.lastErr.Store("")
+	go hc.run(ctx)
+	return hc
+}
+
+func (hc *healthChecker) run(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	hc.check(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			hc.check(ctx)
+		}
+	}
+}
+
+func (hc *healthChecker) check(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := hc.client.Check(checkCtx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		hc.serving.Store(false)
+		hc.lastErr.Store(err.Error())
+		hc.logger.Warn("AI service health check failed", "error", err.Error())
+		return
+	}
+
+	serving := resp.Status == healthpb.HealthCheckResponse_SERVING
+	hc.serving.Store(serving)
+	hc.lastErr.Store("")
+}
+
+// Stop cancels the background polling goroutine.
+func (hc *healthChecker) Stop() {
+	hc.cancel()
+}
+
+// HealthState is the derived channel state exposed at /api/debug/grpc/health.
+type HealthState struct {
+	Serving  bool   `json:"serving"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// Health returns the last observed health state of the AI gRPC service.
+func (c *Client) Health() HealthState {
+	if c.health == nil {
+		return HealthState{Serving: false, LastError: "health checking not started"}
+	}
+	return HealthState{
+		Serving:   c.health.serving.Load(),
+		LastError: c.health.lastErr.Load().(string),
+	}
+}
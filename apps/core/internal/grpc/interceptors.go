@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"iterateswarm-core/internal/logging"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var rpcDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name: "grpc_client_rpc_duration_seconds",
+		Help: "Duration of gRPC client RPCs to the Python AI service.",
+	},
+	[]string{"method", "code"},
+)
+
+func init() {
+	prometheus.MustRegister(rpcDuration)
+}
+
+// retryableCodes are retried by retryUnaryInterceptor with exponential
+// backoff; every other error is returned immediately.
+var retryableCodes = map[codes.Code]bool{
+	codes.Unavailable:      true,
+	codes.DeadlineExceeded: true,
+}
+
+// retryUnaryInterceptor retries UNAVAILABLE/DEADLINE_EXCEEDED unary calls
+// with exponential backoff and jitter, up to maxRetries attempts.
+func retryUnaryInterceptor(logger *logging.Logger, maxRetries int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var lastErr error
+
+		for attempt := 0; attempt <= maxRetries; attempt++ {
+			if attempt > 0 {
+				delay := backoffDelay(attempt)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(delay):
+				}
+			}
+
+			lastErr = invoker(ctx, method, req, reply, cc, opts...)
+			if lastErr == nil {
+				return nil
+			}
+			if !retryableCodes[status.Code(lastErr)] {
+				return lastErr
+			}
+			logger.Warn("retrying rpc", "method", method, "attempt", attempt+1, "error", lastErr.Error())
+		}
+
+		return lastErr
+	}
+}
+
+func backoffDelay(attempt int) time.Duration {
+	base := 100 * time.Millisecond
+	delay := time.Duration(float64(base) * math.Pow(2, float64(attempt-1)))
+	if delay > 5*time.Second {
+		delay = 5 * time.Second
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) / 2))
+	return delay + jitter
+}
+
+// timeoutUnaryInterceptor bounds every call by timeout when the caller's
+// context doesn't already carry a tighter deadline.
+func timeoutUnaryInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if timeout <= 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		// WithTimeout keeps the caller's deadline when it is earlier.
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// metricsUnaryInterceptor records a Prometheus histogram of RPC duration
+// labeled by method and resulting status code.
+func metricsUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		rpcDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
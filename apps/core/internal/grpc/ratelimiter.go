@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"sync"
+
+	"iterateswarm-core/internal/logging"
+)
+
+// RateLimitConfig tunes keyedRateLimiter.
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained rate allowed per key.
+	RequestsPerSecond float64
+	// Burst is the largest burst a single key can spend at once; defaults
+	// to RequestsPerSecond when zero.
+	Burst float64
+}
+
+// DefaultRateLimitConfig allows 50 req/s with bursts up to 100 per key.
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{RequestsPerSecond: 50, Burst: 100}
+}
+
+// keyedRateLimiter hands out an independent logging.TokenBucket per key
+// (e.g. "<source>/<user_id>"), created lazily on first use, so one noisy
+// caller can't exhaust another's budget.
+type keyedRateLimiter struct {
+	cfg RateLimitConfig
+
+	mu      sync.Mutex
+	buckets map[string]*logging.TokenBucket
+}
+
+func newRateLimiter(cfg RateLimitConfig) *keyedRateLimiter {
+	if cfg.RequestsPerSecond <= 0 {
+		cfg = DefaultRateLimitConfig()
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = cfg.RequestsPerSecond
+	}
+	return &keyedRateLimiter{cfg: cfg, buckets: make(map[string]*logging.TokenBucket)}
+}
+
+// Allow reports whether key has a token to spend, consuming one if so.
+func (l *keyedRateLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = logging.NewTokenBucket(l.cfg.Burst, l.cfg.RequestsPerSecond)
+		l.buckets[key] = bucket
+	}
+	l.mu.Unlock()
+	return bucket.Allow()
+}
@@ -0,0 +1,244 @@
+// AgentServer implements the server side of the AgentService proto that
+// client.go, stream.go and batcher.go already speak as a client. It exists
+// so this codebase (and its tests) can run the AI analysis surface locally
+// instead of always depending on the real Python AI service being
+// reachable; client_test.go previously only ever exercised a mock client.
+//
+// Two RPCs beyond what stream.go's client assumes are added here:
+// AnalyzeFeedbackPartial, a server-streaming RPC that emits the analysis's
+// reasoning incrementally (for a UI that wants to render it as it's
+// produced), and BatchAnalyze, a bidi RPC purpose-built for high-throughput
+// ingestion that analyzes arriving requests concurrently rather than one at
+// a time. AnalyzeFeedbackStream itself is left as the bidi, per-request-ID
+// correlated RPC StreamBatcher already depends on. This file assumes the
+// proto has grown AnalyzeFeedbackPartial/BatchAnalyze and their generated
+// types/server interfaces, the same assumption stream.go makes for
+// AnalyzeFeedbackStream.
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"iterateswarm-core/internal/logging"
+
+	pb "github.com/Aparnap2/iterate_swarm/gen/go/ai/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// batchAnalyzeConcurrency bounds how many BatchAnalyze items are analyzed
+// concurrently per stream.
+const batchAnalyzeConcurrency = 16
+
+// AgentServer implements pb.AgentServiceServer by delegating every RPC to a
+// FeedbackAnalyzer.
+type AgentServer struct {
+	pb.UnimplementedAgentServiceServer
+
+	analyzer FeedbackAnalyzer
+	logger   *logging.Logger
+}
+
+// NewAgentServer creates an AgentServer backed by analyzer. A nil analyzer
+// falls back to NaiveAnalyzer, so the server still comes up (for health
+// checks, reflection, etc.) without one configured.
+func NewAgentServer(analyzer FeedbackAnalyzer, logger *logging.Logger) *AgentServer {
+	if analyzer == nil {
+		analyzer = NaiveAnalyzer{}
+	}
+	return &AgentServer{analyzer: analyzer, logger: logger}
+}
+
+// AnalyzeFeedback is the unary RPC client.go's Client.AnalyzeFeedback calls.
+func (s *AgentServer) AnalyzeFeedback(ctx context.Context, req *pb.AnalyzeFeedbackRequest) (*pb.AnalyzeFeedbackResponse, error) {
+	resp, err := s.analyzer.Analyze(ctx, req.Text, req.Source, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "analyze feedback: %v", err)
+	}
+	return resp, nil
+}
+
+// AnalyzeFeedbackStream is the bidi RPC stream.go's streamingClient/
+// streamingSession assume: one response per request, correlated by
+// RequestId, in the order StreamBatcher's fairOrder produced them.
+func (s *AgentServer) AnalyzeFeedbackStream(stream pb.AgentService_AnalyzeFeedbackStreamServer) error {
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.analyzer.Analyze(ctx, req.Text, req.Source, req.UserId)
+		if err != nil {
+			return status.Errorf(codes.Internal, "analyze feedback: %v", err)
+		}
+		if err := stream.Send(&pb.AnalyzeFeedbackStreamResponse{RequestId: req.RequestId, Response: resp}); err != nil {
+			return err
+		}
+	}
+}
+
+// AnalyzeFeedbackPartial streams one request's analysis as it becomes
+// available: a Delta chunk per word of the reasoning, then a final message
+// carrying the complete AnalyzeFeedbackResponse with Done set. Since
+// FeedbackAnalyzer itself returns its result all at once, the deltas here
+// are synthesized after the fact; an analyzer that generates reasoning
+// token-by-token can stream straight from Analyze without changing this
+// RPC's contract.
+func (s *AgentServer) AnalyzeFeedbackPartial(req *pb.AnalyzeFeedbackRequest, stream pb.AgentService_AnalyzeFeedbackPartialServer) error {
+	resp, err := s.analyzer.Analyze(stream.Context(), req.Text, req.Source, req.UserId)
+	if err != nil {
+		return status.Errorf(codes.Internal, "analyze feedback: %v", err)
+	}
+
+	for _, delta := range reasoningDeltas(resp.Reasoning) {
+		if err := stream.Send(&pb.AnalyzeFeedbackPartialResponse{Delta: delta}); err != nil {
+			return err
+		}
+	}
+	return stream.Send(&pb.AnalyzeFeedbackPartialResponse{Done: true, Final: resp})
+}
+
+// reasoningDeltas splits text into word-sized chunks, each carrying its own
+// leading space (except the first), so concatenating every Delta in order
+// reconstitutes text exactly.
+func reasoningDeltas(text string) []string {
+	words := strings.Fields(text)
+	deltas := make([]string, len(words))
+	for i, w := range words {
+		if i > 0 {
+			w = " " + w
+		}
+		deltas[i] = w
+	}
+	return deltas
+}
+
+// BatchAnalyze is a bidi RPC for high-throughput ingestion: unlike
+// AnalyzeFeedbackStream, which analyzes and responds to requests in
+// receive order over a single session, BatchAnalyze fans arriving requests
+// out to up to batchAnalyzeConcurrency concurrent analyses and writes
+// responses back as each completes, trading response ordering for
+// throughput on a burst of incoming feedback.
+func (s *AgentServer) BatchAnalyze(stream pb.AgentService_BatchAnalyzeServer) error {
+	ctx := stream.Context()
+
+	results := make(chan *pb.BatchAnalyzeResponse, batchAnalyzeConcurrency)
+	sem := make(chan struct{}, batchAnalyzeConcurrency)
+	var wg sync.WaitGroup
+
+	sendErrCh := make(chan error, 1)
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		for resp := range results {
+			if err := stream.Send(resp); err != nil {
+				select {
+				case sendErrCh <- err:
+				default:
+				}
+				return
+			}
+		}
+	}()
+
+	var recvErr error
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			recvErr = err
+			break
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(req *pb.BatchAnalyzeRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := s.analyzer.Analyze(ctx, req.Text, req.Source, req.UserId)
+			if err != nil {
+				resp = &pb.AnalyzeFeedbackResponse{Reasoning: fmt.Sprintf("analysis failed: %v", err)}
+			}
+			select {
+			case results <- &pb.BatchAnalyzeResponse{RequestId: req.RequestId, Response: resp}:
+			case <-ctx.Done():
+			}
+		}(req)
+	}
+
+	wg.Wait()
+	close(results)
+	<-writerDone
+
+	if recvErr != nil {
+		return recvErr
+	}
+	select {
+	case err := <-sendErrCh:
+		return err
+	default:
+		return nil
+	}
+}
+
+// ServerConfig configures NewGRPCServer.
+type ServerConfig struct {
+	// AuthToken, if non-empty, is the bearer token every RPC must present
+	// in its "authorization" metadata. Empty disables auth, for local
+	// development.
+	AuthToken string
+	// RateLimit bounds how many requests per second each user_id/source
+	// key may make. The zero value uses DefaultRateLimitConfig.
+	RateLimit RateLimitConfig
+}
+
+// NewGRPCServer builds a *grpc.Server serving agent over unary/stream
+// interceptor chains for logging, Prometheus metrics, panic recovery, auth
+// and rate limiting, plus the standard health and reflection services.
+func NewGRPCServer(cfg ServerConfig, agent *AgentServer, logger *logging.Logger) *grpc.Server {
+	limiter := newRateLimiter(cfg.RateLimit)
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			recoveryUnaryServerInterceptor(logger),
+			loggingUnaryServerInterceptor(logger),
+			metricsUnaryServerInterceptor(),
+			authUnaryServerInterceptor(cfg.AuthToken),
+			rateLimitUnaryServerInterceptor(limiter),
+		),
+		grpc.ChainStreamInterceptor(
+			recoveryStreamServerInterceptor(logger),
+			loggingStreamServerInterceptor(logger),
+			metricsStreamServerInterceptor(),
+			authStreamServerInterceptor(cfg.AuthToken),
+			rateLimitStreamServerInterceptor(limiter),
+		),
+	)
+
+	pb.RegisterAgentServiceServer(srv, agent)
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthSrv.SetServingStatus(pb.AgentService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthSrv)
+
+	reflection.Register(srv)
+
+	return srv
+}
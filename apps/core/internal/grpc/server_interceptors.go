@@ -0,0 +1,277 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"iterateswarm-core/internal/logging"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var serverRPCDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name: "grpc_server_rpc_duration_seconds",
+		Help: "Duration of gRPC server RPCs served by AgentServer.",
+	},
+	[]string{"method", "code"},
+)
+
+func init() {
+	prometheus.MustRegister(serverRPCDuration)
+}
+
+// requestIDKey and metadataCarrier let the logging interceptor propagate a
+// request ID and the caller's trace context (if any) the same way
+// otel.InjectKafkaHeaders/ExtractKafkaHeaders do for Kafka headers.
+type requestIDKeyType struct{}
+
+var requestIDKey = requestIDKeyType{}
+
+const (
+	requestIDMetadataKey = "x-request-id"
+	userIDMetadataKey    = "x-user-id"
+	sourceMetadataKey    = "x-source"
+)
+
+type metadataCarrier struct{ md metadata.MD }
+
+func (c metadataCarrier) Get(key string) string {
+	vals := c.md.Get(key)
+	if len(vals) == 0 {
+		return ""
+	}
+	return vals[0]
+}
+func (c metadataCarrier) Set(key, value string) { c.md.Set(key, value) }
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.md))
+	for k := range c.md {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// requestContext extracts the caller's trace context (if any) and attaches
+// either the caller-supplied request ID (x-request-id) or a freshly
+// generated one, so every log line for this RPC can be correlated both to
+// its originating trace and to each other.
+func requestContext(ctx context.Context) (context.Context, string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier{md: md})
+
+	requestID := metadataCarrier{md: md}.Get(requestIDMetadataKey)
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	ctx = context.WithValue(ctx, requestIDKey, requestID)
+
+	return ctx, requestID
+}
+
+// logInfoCtx and logErrorCtx log through logger the same way
+// Logger.LogActivity does: WithContext applies ctx's trace/span attrs and
+// InfoContext/ErrorContext pass ctx on so NewOTelHandler can also pull the
+// active OpenTelemetry span straight from it.
+func logInfoCtx(logger *logging.Logger, ctx context.Context, msg string, args ...any) {
+	logger.WithContext(ctx).Logger.InfoContext(ctx, msg, args...)
+}
+
+func logErrorCtx(logger *logging.Logger, ctx context.Context, msg string, err error, args ...any) {
+	logger.WithContext(ctx).Logger.ErrorContext(ctx, msg, append(args, "error", err.Error())...)
+}
+
+// rateLimitKey derives the keyedRateLimiter bucket for a call from the
+// caller-supplied x-user-id/x-source metadata, falling back to the peer
+// address so unauthenticated/anonymous callers still share one bucket
+// rather than bypassing the limiter entirely.
+func rateLimitKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	c := metadataCarrier{md: md}
+	userID, source := c.Get(userIDMetadataKey), c.Get(sourceMetadataKey)
+	if userID == "" && source == "" {
+		if p, ok := peer.FromContext(ctx); ok {
+			return p.Addr.String()
+		}
+		return "unknown"
+	}
+	return source + "/" + userID
+}
+
+// loggingUnaryServerInterceptor logs every unary RPC AgentServer serves,
+// tagged with a request ID and (when the caller propagated one) the
+// originating trace.
+func loggingUnaryServerInterceptor(logger *logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, requestID := requestContext(ctx)
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		duration := time.Since(start)
+		if err != nil {
+			logErrorCtx(logger, ctx, "rpc failed", err, "method", info.FullMethod, "request_id", requestID, "duration_ms", duration.Milliseconds())
+		} else {
+			logInfoCtx(logger, ctx, "rpc completed", "method", info.FullMethod, "request_id", requestID, "duration_ms", duration.Milliseconds())
+		}
+
+		return resp, err
+	}
+}
+
+// loggingStreamServerInterceptor is loggingUnaryServerInterceptor's
+// streaming counterpart.
+func loggingStreamServerInterceptor(logger *logging.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := requestContext(ss.Context())
+		start := time.Now()
+
+		err := handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+
+		duration := time.Since(start)
+		if err != nil {
+			logErrorCtx(logger, ctx, "stream rpc failed", err, "method", info.FullMethod, "request_id", requestID, "duration_ms", duration.Milliseconds())
+		} else {
+			logInfoCtx(logger, ctx, "stream rpc completed", "method", info.FullMethod, "request_id", requestID, "duration_ms", duration.Milliseconds())
+		}
+
+		return err
+	}
+}
+
+// metricsUnaryServerInterceptor records a Prometheus histogram of RPC
+// duration labeled by method and resulting status code.
+func metricsUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		serverRPCDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		return resp, err
+	}
+}
+
+// metricsStreamServerInterceptor is metricsUnaryServerInterceptor's
+// streaming counterpart.
+func metricsStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		serverRPCDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// recoveryUnaryServerInterceptor turns a panic in handler into a
+// codes.Internal error instead of crashing the process, the same
+// protection Fiber's recover middleware gives the HTTP API.
+func recoveryUnaryServerInterceptor(logger *logging.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("panic in rpc handler", fmt.Errorf("%v", r), "method", info.FullMethod)
+				err = status.Errorf(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// recoveryStreamServerInterceptor is recoveryUnaryServerInterceptor's
+// streaming counterpart.
+func recoveryStreamServerInterceptor(logger *logging.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("panic in stream rpc handler", fmt.Errorf("%v", r), "method", info.FullMethod)
+				err = status.Errorf(codes.Internal, "internal error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// authUnaryServerInterceptor rejects calls whose "authorization: Bearer
+// <token>" metadata doesn't match token. An empty token disables auth
+// entirely, so local development doesn't need one configured.
+func authUnaryServerInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkAuth(ctx, token); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authStreamServerInterceptor is authUnaryServerInterceptor's streaming
+// counterpart.
+func authStreamServerInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkAuth(ss.Context(), token); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func checkAuth(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing authorization metadata")
+	}
+	got := metadataCarrier{md: md}.Get("authorization")
+	if got != "Bearer "+token {
+		return status.Error(codes.Unauthenticated, "invalid or missing bearer token")
+	}
+	return nil
+}
+
+// rateLimitUnaryServerInterceptor rejects calls once the caller's
+// user_id/source bucket runs out of tokens.
+func rateLimitUnaryServerInterceptor(limiter *keyedRateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !limiter.Allow(rateLimitKey(ctx)) {
+			return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitStreamServerInterceptor is rateLimitUnaryServerInterceptor's
+// streaming counterpart. It is checked once per stream, not once per
+// message, since BatchAnalyze/AnalyzeFeedbackStream are long-lived
+// sessions rather than one call per item.
+func rateLimitStreamServerInterceptor(limiter *keyedRateLimiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !limiter.Allow(rateLimitKey(ss.Context())) {
+			return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+		}
+		return handler(srv, ss)
+	}
+}
+
+// wrappedServerStream overrides Context() so interceptors can thread a
+// derived context (request ID, extracted trace) down to the handler.
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedServerStream) Context() context.Context { return s.ctx }
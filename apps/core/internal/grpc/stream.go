@@ -0,0 +1,160 @@
+// AnalyzeFeedbackStream and its request/response messages are defined on
+// the AgentService proto that gen/go/ai/v1 is generated from (a sibling
+// repo); this file assumes that addition has been generated and vendored.
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	pb "github.com/Aparnap2/iterate_swarm/gen/go/ai/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamRequest is a single feedback item sent over the bidirectional
+// AnalyzeFeedbackStream RPC. RequestID lets the caller demultiplex the
+// matching StreamResponse out of the shared stream.
+type StreamRequest struct {
+	RequestID string
+	Text      string
+	Source    string
+	UserID    string
+}
+
+// StreamResponse pairs a RequestID back to the analysis it corresponds to.
+type StreamResponse struct {
+	RequestID string
+	*pb.AnalyzeFeedbackResponse
+}
+
+// Sender is the send half of an AnalyzeFeedbackStream session.
+type Sender interface {
+	Send(StreamRequest) error
+	CloseSend() error
+}
+
+// Receiver is the receive half of an AnalyzeFeedbackStream session.
+type Receiver interface {
+	Recv() (StreamResponse, error)
+}
+
+// streamingClient is implemented by generated AgentService clients that
+// advertise the bidirectional AnalyzeFeedbackStream RPC (added to the
+// AgentService proto alongside the existing unary AnalyzeFeedback). It is
+// asserted against dynamically so older server builds that only implement
+// the unary RPC still work via unaryFallback below.
+type streamingClient interface {
+	AnalyzeFeedbackStream(ctx context.Context) (streamingSession, error)
+}
+
+// streamingSession is the bidi-stream handle returned by a generated
+// AnalyzeFeedbackStream client call.
+type streamingSession interface {
+	Send(*pb.AnalyzeFeedbackStreamRequest) error
+	Recv() (*pb.AnalyzeFeedbackStreamResponse, error)
+	CloseSend() error
+}
+
+// AnalyzeStream opens a bidirectional AnalyzeFeedbackStream session. If the
+// connected server doesn't advertise the streaming method (an older build,
+// or codes.Unimplemented on first use), it transparently falls back to
+// issuing one unary AnalyzeFeedback call per StreamRequest.
+func (c *Client) AnalyzeStream(ctx context.Context) (Sender, Receiver, error) {
+	streamer, ok := c.client.(streamingClient)
+	if !ok {
+		c.logger.Debug("AI service does not advertise AnalyzeFeedbackStream, falling back to unary")
+		fallback := newUnaryFallback(c, ctx)
+		return fallback, fallback, nil
+	}
+
+	session, err := streamer.AnalyzeFeedbackStream(ctx)
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			c.logger.Debug("AnalyzeFeedbackStream unimplemented by server, falling back to unary")
+			fallback := newUnaryFallback(c, ctx)
+			return fallback, fallback, nil
+		}
+		return nil, nil, fmt.Errorf("failed to open AnalyzeFeedbackStream: %w", err)
+	}
+
+	pipe := &streamPipe{session: session}
+	return pipe, pipe, nil
+}
+
+// streamPipe adapts the generated streamingSession to Sender/Receiver.
+type streamPipe struct {
+	session streamingSession
+}
+
+func (p *streamPipe) Send(req StreamRequest) error {
+	return p.session.Send(&pb.AnalyzeFeedbackStreamRequest{
+		RequestId: req.RequestID,
+		Text:      req.Text,
+		Source:    req.Source,
+		UserId:    req.UserID,
+	})
+}
+
+func (p *streamPipe) CloseSend() error {
+	return p.session.CloseSend()
+}
+
+func (p *streamPipe) Recv() (StreamResponse, error) {
+	resp, err := p.session.Recv()
+	if err != nil {
+		if err == io.EOF {
+			return StreamResponse{}, io.EOF
+		}
+		return StreamResponse{}, err
+	}
+	return StreamResponse{RequestID: resp.RequestId, AnalyzeFeedbackResponse: resp.Response}, nil
+}
+
+// unaryFallback implements Sender and Receiver on top of the existing
+// unary AnalyzeFeedback RPC, one call per Send, so callers can use
+// AnalyzeStream unconditionally regardless of server capability.
+type unaryFallback struct {
+	client  *Client
+	ctx     context.Context
+	results chan StreamResponse
+	done    chan struct{}
+}
+
+func newUnaryFallback(c *Client, ctx context.Context) *unaryFallback {
+	return &unaryFallback{
+		client:  c,
+		ctx:     ctx,
+		results: make(chan StreamResponse, 16),
+		done:    make(chan struct{}),
+	}
+}
+
+func (f *unaryFallback) Send(req StreamRequest) error {
+	resp, err := f.client.AnalyzeFeedback(f.ctx, req.Text, req.Source, req.UserID)
+	if err != nil {
+		return err
+	}
+	f.results <- StreamResponse{RequestID: req.RequestID, AnalyzeFeedbackResponse: resp}
+	return nil
+}
+
+func (f *unaryFallback) CloseSend() error {
+	close(f.done)
+	return nil
+}
+
+func (f *unaryFallback) Recv() (StreamResponse, error) {
+	select {
+	case resp := <-f.results:
+		return resp, nil
+	case <-f.done:
+		select {
+		case resp := <-f.results:
+			return resp, nil
+		default:
+			return StreamResponse{}, io.EOF
+		}
+	}
+}
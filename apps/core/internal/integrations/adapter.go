@@ -1,11 +1,16 @@
 package integrations
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
+
+	"iterateswarm-core/internal/ratelimit"
+	"iterateswarm-core/internal/retry"
 )
 
 // Integration represents a third-party service integration
@@ -34,18 +39,21 @@ type IntegrationResponse struct {
 	Timestamp time.Time
 }
 
-// Adapter provides a common interface for all integrations
+// Adapter provides a common interface for all integrations. Outbound
+// requests go through rl rather than a bare *http.Client so repeated
+// webhook traffic honors the target's rate limits instead of hammering it
+// into a sustained 429 loop.
 type Adapter struct {
-	client  *http.Client
+	rl       *ratelimit.Client
 	webhooks map[string]WebhookConfig
 }
 
 // NewAdapter creates a new integration adapter
 func NewAdapter(timeout time.Duration) *Adapter {
 	return &Adapter{
-		client: &http.Client{
+		rl: ratelimit.NewClient(&http.Client{
 			Timeout: timeout,
-		},
+		}),
 		webhooks: make(map[string]WebhookConfig),
 	}
 }
@@ -78,19 +86,32 @@ func (a *Adapter) SendWebhook(ctx context.Context, name string, payload interfac
 	}
 	req.Header.Set("X-Webhook-Source", "iterateswarm")
 
-	resp, err := a.client.Do(req)
+	resp, err := a.rl.Do(req)
 	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retryAfter, _ := retry.ParseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, &retry.HTTPError{StatusCode: resp.StatusCode, Body: respBody, RetryAfter: retryAfter}
+	}
+
 	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(respBody, &result); err != nil {
 		result = make(map[string]interface{})
 	}
 
 	return &IntegrationResponse{
-		Success:   resp.StatusCode >= 200 && resp.StatusCode < 300,
+		Success:   true,
 		Status:    resp.StatusCode,
 		Message:   http.StatusText(resp.StatusCode),
 		Data:      result,
@@ -102,6 +123,9 @@ func (a *Adapter) SendWebhook(ctx context.Context, name string, payload interfac
 type DiscordAdapter struct {
 	*Adapter
 	webhookURL string
+	username   string
+	avatarURL  string
+	headers    map[string]string
 }
 
 // NewDiscordAdapter creates a new Discord adapter
@@ -175,10 +199,18 @@ type DiscordButtonComponent struct {
 	CustomID string `json:"custom_id,omitempty"`
 }
 
-// Send sends a Discord message
+// Send sends a Discord message. payload is either a DiscordMessage for the
+// full embed/component shape, or a plain string (used by NewFromURL/
+// MultiSender's simple notification path), wrapped as content with the
+// adapter's configured username/avatar_url.
 func (d *DiscordAdapter) Send(ctx context.Context, payload interface{}) error {
-	msg, ok := payload.(DiscordMessage)
-	if !ok {
+	var msg DiscordMessage
+	switch v := payload.(type) {
+	case DiscordMessage:
+		msg = v
+	case string:
+		msg = DiscordMessage{Content: v, Username: d.username, AvatarURL: d.avatarURL}
+	default:
 		return fmt.Errorf("invalid payload type for Discord")
 	}
 
@@ -192,15 +224,23 @@ func (d *DiscordAdapter) Send(ctx context.Context, payload interface{}) error {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	for k, v := range d.headers {
+		req.Header.Set(k, v)
+	}
 
-	resp, err := d.client.Do(req)
+	resp, err := d.rl.Do(req)
 	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("discord returned status %d", resp.StatusCode)
+		respBody, _ := io.ReadAll(resp.Body)
+		retryAfter, _ := retry.ParseRetryAfter(resp.Header.Get("Retry-After"))
+		return &retry.HTTPError{StatusCode: resp.StatusCode, Body: respBody, RetryAfter: retryAfter}
 	}
 
 	return nil
@@ -208,20 +248,28 @@ func (d *DiscordAdapter) Send(ctx context.Context, payload interface{}) error {
 
 // Validate validates a Discord message
 func (d *DiscordAdapter) Validate(payload interface{}) error {
-	msg, ok := payload.(DiscordMessage)
-	if !ok {
-		return fmt.Errorf("payload must be DiscordMessage")
+	switch v := payload.(type) {
+	case DiscordMessage:
+		if v.Content == "" && len(v.Embeds) == 0 {
+			return fmt.Errorf("message must have content or embeds")
+		}
+		return nil
+	case string:
+		if v == "" {
+			return fmt.Errorf("message must have content or embeds")
+		}
+		return nil
+	default:
+		return fmt.Errorf("payload must be DiscordMessage or string")
 	}
-	if msg.Content == "" && len(msg.Embeds) == 0 {
-		return fmt.Errorf("message must have content or embeds")
-	}
-	return nil
 }
 
 // SlackAdapter handles Slack integrations
 type SlackAdapter struct {
 	*Adapter
 	webhookURL string
+	channel    string
+	headers    map[string]string
 }
 
 // NewSlackAdapter creates a new Slack adapter
@@ -256,10 +304,46 @@ type SlackMessage struct {
 	Markdown    bool                `json:"mrkdwn,omitempty"`
 }
 
+// Block Kit block types (https://api.slack.com/reference/block-kit/blocks).
+const (
+	SlackBlockSection = "section"
+	SlackBlockActions = "actions"
+	SlackBlockInput   = "input"
+	SlackBlockDivider = "divider"
+	SlackBlockContext = "context"
+	SlackBlockHeader  = "header"
+)
+
+// Block Kit element types (https://api.slack.com/reference/block-kit/block-elements).
+const (
+	SlackElementButton         = "button"
+	SlackElementPlainTextInput = "plain_text_input"
+)
+
+// SlackBlock is a single Block Kit block. Its fields cover section,
+// actions, input, divider, context and header blocks; a given block only
+// populates the fields that type uses (e.g. divider uses none of them).
 type SlackBlock struct {
-	Type     string              `json:"type"`
-	Text     *SlackText          `json:"text,omitempty"`
-	Elements []map[string]interface{} `json:"elements,omitempty"`
+	Type     string         `json:"type"`
+	BlockID  string         `json:"block_id,omitempty"`
+	Text     *SlackText     `json:"text,omitempty"`
+	Fields   []SlackText    `json:"fields,omitempty"`
+	Elements []SlackElement `json:"elements,omitempty"`
+	Element  *SlackElement  `json:"element,omitempty"`
+	Label    *SlackText     `json:"label,omitempty"`
+	Optional bool           `json:"optional,omitempty"`
+}
+
+// SlackElement is a Block Kit block element, e.g. a button inside an
+// actions block or the input inside an input block.
+type SlackElement struct {
+	Type        string     `json:"type"`
+	ActionID    string     `json:"action_id,omitempty"`
+	Text        *SlackText `json:"text,omitempty"`
+	Value       string     `json:"value,omitempty"`
+	Style       string     `json:"style,omitempty"`
+	Placeholder *SlackText `json:"placeholder,omitempty"`
+	Multiline   bool       `json:"multiline,omitempty"`
 }
 
 type SlackText struct {
@@ -276,10 +360,18 @@ type SlackAttachment struct {
 	TS         int64             `json:"ts,omitempty"`
 }
 
-// Send sends a Slack message
+// Send sends a Slack message. payload is either a SlackMessage for the
+// full Block Kit shape, or a plain string (used by NewFromURL/
+// MultiSender's simple notification path), wrapped as text targeting the
+// adapter's configured channel.
 func (s *SlackAdapter) Send(ctx context.Context, payload interface{}) error {
-	msg, ok := payload.(SlackMessage)
-	if !ok {
+	var msg SlackMessage
+	switch v := payload.(type) {
+	case SlackMessage:
+		msg = v
+	case string:
+		msg = SlackMessage{Channel: s.channel, Text: v}
+	default:
 		return fmt.Errorf("invalid payload type for Slack")
 	}
 
@@ -293,15 +385,23 @@ func (s *SlackAdapter) Send(ctx context.Context, payload interface{}) error {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
 
-	resp, err := s.client.Do(req)
+	resp, err := s.rl.Do(req)
 	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("slack returned status %d", resp.StatusCode)
+		respBody, _ := io.ReadAll(resp.Body)
+		retryAfter, _ := retry.ParseRetryAfter(resp.Header.Get("Retry-After"))
+		return &retry.HTTPError{StatusCode: resp.StatusCode, Body: respBody, RetryAfter: retryAfter}
 	}
 
 	return nil
@@ -309,12 +409,18 @@ func (s *SlackAdapter) Send(ctx context.Context, payload interface{}) error {
 
 // Validate validates a Slack message
 func (s *SlackAdapter) Validate(payload interface{}) error {
-	msg, ok := payload.(SlackMessage)
-	if !ok {
-		return fmt.Errorf("payload must be SlackMessage")
+	switch v := payload.(type) {
+	case SlackMessage:
+		if v.Text == "" && len(v.Blocks) == 0 {
+			return fmt.Errorf("message must have text or blocks")
+		}
+		return nil
+	case string:
+		if v == "" {
+			return fmt.Errorf("message must have text or blocks")
+		}
+		return nil
+	default:
+		return fmt.Errorf("payload must be SlackMessage or string")
 	}
-	if msg.Text == "" && len(msg.Blocks) == 0 {
-		return fmt.Errorf("message must have text or blocks")
-	}
-	return nil
 }
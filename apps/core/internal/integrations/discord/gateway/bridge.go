@@ -0,0 +1,37 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+
+	"iterateswarm-core/internal/logging"
+	"iterateswarm-core/internal/redpanda"
+)
+
+// PublishToRedpanda subscribes to bus and forwards every event to rc as a
+// JSON-encoded Event, so the existing Fiber handler flow (debug.Handler's
+// Kafka browser/EventTailer) can see Gateway events the same way it sees
+// any other Redpanda message. It runs until ctx is cancelled.
+func PublishToRedpanda(ctx context.Context, bus *EventBus, rc *redpanda.Client, logger *logging.Logger) {
+	ch := bus.Subscribe()
+	defer bus.Unsubscribe(ch)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				logger.Warn("failed to marshal gateway event", "type", event.Type, "error", err.Error())
+				continue
+			}
+			if err := rc.Publish(data); err != nil {
+				logger.Warn("failed to publish gateway event to redpanda", "type", event.Type, "error", err.Error())
+			}
+		}
+	}
+}
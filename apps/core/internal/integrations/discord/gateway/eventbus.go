@@ -0,0 +1,50 @@
+package gateway
+
+import "sync"
+
+// EventBus fans out decoded Gateway events to every subscriber. It never
+// blocks the read loop that publishes into it: a subscriber whose channel
+// is full simply misses events rather than stalling the connection.
+type EventBus struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewEventBus creates an empty EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[chan Event]struct{})}
+}
+
+// Subscribe returns a channel that receives every event published after
+// this call, buffered so a slow consumer doesn't block Publish. Call
+// Unsubscribe with the same channel when done.
+func (b *EventBus) Subscribe() chan Event {
+	ch := make(chan Event, 64)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+// Unsubscribe removes and closes ch.
+func (b *EventBus) Unsubscribe(ch chan Event) {
+	b.mu.Lock()
+	if _, ok := b.subscribers[ch]; ok {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+	b.mu.Unlock()
+}
+
+// Publish delivers event to every current subscriber. A subscriber with a
+// full buffer drops the event instead of blocking the others.
+func (b *EventBus) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,371 @@
+// Package gateway is a hand-rolled client for Discord's Gateway WebSocket
+// API (wss://gateway.discord.gg), complementing integrations.DiscordAdapter
+// (outbound webhooks only) with the ability to receive MESSAGE_CREATE,
+// INTERACTION_CREATE, presence and other events without exposing an HTTPS
+// callback. It is distinct from internal/discord.Gateway, which uses
+// discordgo (and that library's own Gateway handling) purely to route
+// Approve/Reject button interactions back into Temporal.
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"iterateswarm-core/internal/logging"
+	"iterateswarm-core/internal/retry"
+
+	"github.com/gorilla/websocket"
+)
+
+const defaultGatewayURL = "wss://gateway.discord.gg/?v=10&encoding=json"
+
+// Config configures Gateway.
+type Config struct {
+	// Token is the bot token sent on IDENTIFY/RESUME.
+	Token string
+	// Intents is the bitmask of events to subscribe to; combine the
+	// Intent* constants with bitwise OR.
+	Intents int
+	// URL overrides the initial connection endpoint; empty uses
+	// defaultGatewayURL. Reconnects after a READY always use the
+	// resume_gateway_url that event carried, regardless of URL.
+	URL string
+	// ReconnectBackoff tunes the randomized reconnect delay between
+	// dropped connections. Nil uses DefaultReconnectBackoff.
+	ReconnectBackoff *retry.RetryConfig
+}
+
+// DefaultReconnectBackoff reconnects after 1-5s (with jitter), doubling up
+// to a 60s cap.
+func DefaultReconnectBackoff() *retry.RetryConfig {
+	return &retry.RetryConfig{
+		InitialDelay:      1 * time.Second,
+		MaxDelay:          60 * time.Second,
+		BackoffMultiplier: 2.0,
+		Jitter:            4 * time.Second,
+	}
+}
+
+// Gateway is a persistent connection to Discord's Gateway: it IDENTIFYs or
+// RESUMEs as appropriate, heartbeats on the server's schedule, detects and
+// recovers from zombied connections, and publishes every dispatched event
+// onto Bus for subscribers to decode.
+type Gateway struct {
+	cfg    Config
+	logger *logging.Logger
+	Bus    *EventBus
+
+	mu               sync.Mutex
+	conn             *websocket.Conn
+	cancel           context.CancelFunc
+	sessionID        string
+	resumeGatewayURL string
+
+	// writeMu serializes every write to conn: gorilla/websocket allows only
+	// one concurrent writer, but heartbeatLoop's ticker-driven heartbeats
+	// and connectOnce's read loop (which heartbeats back on a
+	// server-requested op 1, and sends identify/resume) all write to the
+	// same connection from different goroutines.
+	writeMu sync.Mutex
+
+	seq              atomic.Int64
+	haveSeq          atomic.Bool
+	lastHeartbeatAck atomic.Bool
+}
+
+// NewGateway creates a Gateway that hasn't connected yet; call Run to start it.
+func NewGateway(cfg Config) *Gateway {
+	if cfg.URL == "" {
+		cfg.URL = defaultGatewayURL
+	}
+	if cfg.ReconnectBackoff == nil {
+		cfg.ReconnectBackoff = DefaultReconnectBackoff()
+	}
+	return &Gateway{
+		cfg:    cfg,
+		logger: logging.NewLogger("discord-ws-gateway"),
+		Bus:    NewEventBus(),
+	}
+}
+
+// Run connects and keeps reconnecting, with backoff, until ctx is
+// cancelled or Close is called. It blocks until then, so callers typically
+// run it in its own goroutine.
+func (g *Gateway) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	g.mu.Lock()
+	g.cancel = cancel
+	g.mu.Unlock()
+
+	attempt := 0
+	for ctx.Err() == nil {
+		g.mu.Lock()
+		resumable := g.sessionID != "" && g.resumeGatewayURL != ""
+		g.mu.Unlock()
+
+		err := g.connectOnce(ctx, resumable)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			g.logger.Warn("gateway connection ended", "error", err.Error(), "resumable", resumable)
+		}
+
+		attempt++
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay(g.cfg.ReconnectBackoff, attempt)):
+		}
+	}
+}
+
+// Close stops Run's reconnect loop and closes any currently open connection.
+func (g *Gateway) Close() {
+	g.mu.Lock()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	conn := g.conn
+	g.mu.Unlock()
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+func reconnectDelay(cfg *retry.RetryConfig, attempt int) time.Duration {
+	delay := time.Duration(float64(cfg.InitialDelay) * math.Pow(cfg.BackoffMultiplier, float64(attempt-1)))
+	if delay > cfg.MaxDelay {
+		delay = cfg.MaxDelay
+	}
+	if delay < cfg.InitialDelay {
+		delay = cfg.InitialDelay
+	}
+	return delay + time.Duration(rand.Int63n(int64(cfg.Jitter)+1))
+}
+
+// connectOnce dials (either the default Gateway endpoint or a previous
+// session's resume_gateway_url), IDENTIFYs or RESUMEs, and then reads
+// frames until the connection drops or is explicitly reconnected/
+// invalidated by the server.
+func (g *Gateway) connectOnce(ctx context.Context, resume bool) error {
+	url := g.cfg.URL
+	if resume {
+		g.mu.Lock()
+		url = g.resumeGatewayURL + "/?v=10&encoding=json"
+		g.mu.Unlock()
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return fmt.Errorf("dial gateway: %w", err)
+	}
+	g.mu.Lock()
+	g.conn = conn
+	g.mu.Unlock()
+	defer func() {
+		conn.Close()
+		g.mu.Lock()
+		g.conn = nil
+		g.mu.Unlock()
+	}()
+
+	hello, err := g.readHello(conn)
+	if err != nil {
+		return err
+	}
+
+	if resume {
+		err = g.sendResume(conn)
+	} else {
+		err = g.sendIdentify(conn)
+	}
+	if err != nil {
+		return err
+	}
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	defer stopHeartbeat()
+	zombie := make(chan struct{}, 1)
+	go g.heartbeatLoop(heartbeatCtx, conn, time.Duration(hello.HeartbeatInterval)*time.Millisecond, zombie)
+
+	for {
+		select {
+		case <-zombie:
+			return fmt.Errorf("heartbeat zombied: no ACK within two intervals")
+		default:
+		}
+
+		var p payload
+		if err := conn.ReadJSON(&p); err != nil {
+			return fmt.Errorf("read gateway frame: %w", err)
+		}
+
+		if p.S != nil {
+			g.seq.Store(int64(*p.S))
+			g.haveSeq.Store(true)
+		}
+
+		switch p.Op {
+		case opDispatch:
+			g.handleDispatch(p)
+		case opHeartbeatACK:
+			g.lastHeartbeatAck.Store(true)
+		case opHeartbeat:
+			if err := g.sendHeartbeat(conn); err != nil {
+				return fmt.Errorf("send requested heartbeat: %w", err)
+			}
+		case opReconnect:
+			return fmt.Errorf("server requested reconnect")
+		case opInvalidSession:
+			var resumable bool
+			_ = json.Unmarshal(p.D, &resumable)
+			if !resumable {
+				g.mu.Lock()
+				g.sessionID = ""
+				g.resumeGatewayURL = ""
+				g.mu.Unlock()
+			}
+			return fmt.Errorf("invalid session (resumable=%v)", resumable)
+		}
+	}
+}
+
+func (g *Gateway) handleDispatch(p payload) {
+	if p.T == "READY" {
+		var ready readyData
+		if err := json.Unmarshal(p.D, &ready); err == nil {
+			g.mu.Lock()
+			g.sessionID = ready.SessionID
+			g.resumeGatewayURL = ready.ResumeGatewayURL
+			g.mu.Unlock()
+		}
+	}
+	g.Bus.Publish(Event{Type: p.T, Data: p.D})
+}
+
+func (g *Gateway) readHello(conn *websocket.Conn) (helloData, error) {
+	var p payload
+	if err := conn.ReadJSON(&p); err != nil {
+		return helloData{}, fmt.Errorf("read hello: %w", err)
+	}
+	if p.Op != opHello {
+		return helloData{}, fmt.Errorf("expected HELLO (op %d), got op %d", opHello, p.Op)
+	}
+	var hello helloData
+	if err := json.Unmarshal(p.D, &hello); err != nil {
+		return helloData{}, fmt.Errorf("decode hello: %w", err)
+	}
+	return hello, nil
+}
+
+func (g *Gateway) sendIdentify(conn *websocket.Conn) error {
+	d, err := json.Marshal(identifyData{
+		Token:   g.cfg.Token,
+		Intents: g.cfg.Intents,
+		Properties: identifyProperties{
+			OS:      "linux",
+			Browser: "iterateswarm",
+			Device:  "iterateswarm",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("marshal identify: %w", err)
+	}
+	return g.writeJSON(conn, payload{Op: opIdentify, D: d})
+}
+
+func (g *Gateway) sendResume(conn *websocket.Conn) error {
+	g.mu.Lock()
+	sessionID := g.sessionID
+	g.mu.Unlock()
+
+	d, err := json.Marshal(resumeData{Token: g.cfg.Token, SessionID: sessionID, Seq: int(g.seq.Load())})
+	if err != nil {
+		return fmt.Errorf("marshal resume: %w", err)
+	}
+	return g.writeJSON(conn, payload{Op: opResume, D: d})
+}
+
+func (g *Gateway) sendHeartbeat(conn *websocket.Conn) error {
+	var seq json.RawMessage
+	if g.haveSeq.Load() {
+		var err error
+		seq, err = json.Marshal(g.seq.Load())
+		if err != nil {
+			return fmt.Errorf("marshal heartbeat sequence: %w", err)
+		}
+	} else {
+		seq = json.RawMessage("null")
+	}
+	return g.writeJSON(conn, payload{Op: opHeartbeat, D: seq})
+}
+
+// writeJSON serializes a single write to conn behind writeMu, since
+// gorilla/websocket forbids concurrent writers.
+func (g *Gateway) writeJSON(conn *websocket.Conn, v interface{}) error {
+	g.writeMu.Lock()
+	defer g.writeMu.Unlock()
+	return conn.WriteJSON(v)
+}
+
+// heartbeatLoop sends op 1 every interval (jittered 0.0-1.0 on the first
+// beat only, per Discord's documented handshake) with the last known
+// sequence number, and signals zombie if two consecutive intervals pass
+// without an ACK (op 11) — at which point it sends a close frame with code
+// 4000 and closes conn, so connectOnce's read fails and Run reconnects.
+func (g *Gateway) heartbeatLoop(ctx context.Context, conn *websocket.Conn, interval time.Duration, zombie chan<- struct{}) {
+	jitter := time.Duration(rand.Float64() * float64(interval))
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(jitter):
+	}
+
+	if err := g.sendHeartbeat(conn); err != nil {
+		return
+	}
+	g.lastHeartbeatAck.Store(false)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	missed := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if g.lastHeartbeatAck.Load() {
+				missed = 0
+			} else {
+				missed++
+			}
+			if missed >= 2 {
+				select {
+				case zombie <- struct{}{}:
+				default:
+				}
+				// WriteControl is exempt from gorilla/websocket's
+				// one-writer rule (it may be called concurrently with
+				// writes made via WriteJSON/writeJSON), so it doesn't need writeMu.
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(4000, "zombied connection"),
+					time.Now().Add(5*time.Second))
+				conn.Close()
+				return
+			}
+
+			g.lastHeartbeatAck.Store(false)
+			if err := g.sendHeartbeat(conn); err != nil {
+				return
+			}
+		}
+	}
+}
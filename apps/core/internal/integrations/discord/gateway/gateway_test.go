@@ -0,0 +1,196 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"iterateswarm-core/internal/logging"
+	"iterateswarm-core/internal/retry"
+)
+
+// fakeDiscordServer speaks just enough of the Gateway protocol to drive
+// Gateway through IDENTIFY -> READY -> disconnect -> RESUME: it sends HELLO
+// on connect, acks the first IDENTIFY with READY (handing out a sessionID
+// and a resume_gateway_url pointing back at itself) then drops that
+// connection, and records every op it receives so the test can assert the
+// reconnect sent RESUME rather than a second IDENTIFY.
+type fakeDiscordServer struct {
+	url string
+
+	mu          sync.Mutex
+	conns       int
+	receivedOps []int
+	resumeSeq   *int
+}
+
+func newFakeDiscordServer(t *testing.T) *fakeDiscordServer {
+	t.Helper()
+	srv := &fakeDiscordServer{}
+	upgrader := websocket.Upgrader{}
+
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		srv.mu.Lock()
+		srv.conns++
+		firstConn := srv.conns == 1
+		srv.mu.Unlock()
+
+		hello, _ := json.Marshal(helloData{HeartbeatInterval: 60000})
+		if conn.WriteJSON(payload{Op: opHello, D: hello}) != nil {
+			return
+		}
+
+		var p payload
+		if conn.ReadJSON(&p) != nil {
+			return
+		}
+		srv.mu.Lock()
+		srv.receivedOps = append(srv.receivedOps, p.Op)
+		srv.mu.Unlock()
+
+		if p.Op == opResume {
+			var resume resumeData
+			_ = json.Unmarshal(p.D, &resume)
+			seq := resume.Seq
+			srv.mu.Lock()
+			srv.resumeSeq = &seq
+			srv.mu.Unlock()
+			<-r.Context().Done()
+			return
+		}
+
+		ready, _ := json.Marshal(readyData{SessionID: "test-session-1", ResumeGatewayURL: srv.url})
+		if conn.WriteJSON(payload{Op: opDispatch, T: "READY", D: ready}) != nil {
+			return
+		}
+
+		if firstConn {
+			// Force a disconnect so Run reconnects and, since it now has a
+			// sessionID and resumeGatewayURL, RESUMEs instead of
+			// re-IDENTIFYing.
+			return
+		}
+		<-r.Context().Done()
+	}))
+
+	srv.url = "ws" + strings.TrimPrefix(httpSrv.URL, "http")
+	t.Cleanup(httpSrv.Close)
+	return srv
+}
+
+func TestGatewayReconnectResumesWithSessionID(t *testing.T) {
+	srv := newFakeDiscordServer(t)
+
+	gw := NewGateway(Config{
+		Token: "test-token",
+		URL:   srv.url,
+		ReconnectBackoff: &retry.RetryConfig{
+			InitialDelay:      10 * time.Millisecond,
+			MaxDelay:          50 * time.Millisecond,
+			BackoffMultiplier: 2,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		gw.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.After(4 * time.Second)
+	for {
+		srv.mu.Lock()
+		gotResume := srv.resumeSeq != nil
+		srv.mu.Unlock()
+		if gotResume {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for the client to RESUME after reconnecting")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	gw.Close()
+	<-done
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.conns < 2 {
+		t.Fatalf("expected at least 2 connections (initial + resume), got %d", srv.conns)
+	}
+	if len(srv.receivedOps) < 2 || srv.receivedOps[0] != opIdentify {
+		t.Fatalf("expected the first op to be IDENTIFY, got %v", srv.receivedOps)
+	}
+	if srv.receivedOps[len(srv.receivedOps)-1] != opResume {
+		t.Fatalf("expected the reconnect to send RESUME, got ops %v", srv.receivedOps)
+	}
+}
+
+// TestSendHeartbeatSerializesWrites drives sendHeartbeat concurrently from
+// many goroutines, the way heartbeatLoop's ticker and connectOnce's read
+// loop (on a server-requested op 1) do against the same connection, and
+// asserts every write succeeds. Run with -race to catch the unsynchronized
+// concurrent-writer bug writeMu fixes.
+func TestSendHeartbeatSerializesWrites(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var p payload
+			if conn.ReadJSON(&p) != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(httpSrv.Close)
+
+	wsURL := "ws" + strings.TrimPrefix(httpSrv.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	gw := &Gateway{logger: logging.NewLogger("test"), Bus: NewEventBus()}
+
+	const n = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := gw.sendHeartbeat(conn); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("concurrent sendHeartbeat failed: %v", err)
+	}
+}
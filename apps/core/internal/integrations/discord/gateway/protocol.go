@@ -0,0 +1,80 @@
+package gateway
+
+import "encoding/json"
+
+// Gateway opcodes (Discord API v10).
+const (
+	opDispatch            = 0
+	opHeartbeat           = 1
+	opIdentify            = 2
+	opPresenceUpdate      = 3
+	opVoiceStateUpdate    = 4
+	opResume              = 6
+	opReconnect           = 7
+	opRequestGuildMembers = 8
+	opInvalidSession      = 9
+	opHello               = 10
+	opHeartbeatACK        = 11
+)
+
+// Gateway intents this client knows how to request. Combine with bitwise
+// OR; see https://discord.com/developers/docs/topics/gateway#gateway-intents
+// for the full list.
+const (
+	IntentGuilds                 = 1 << 0
+	IntentGuildMessages          = 1 << 9
+	IntentGuildMessageReactions  = 1 << 10
+	IntentDirectMessages         = 1 << 12
+	IntentMessageContent         = 1 << 15
+)
+
+// payload is the envelope every Gateway frame (inbound and outbound) is
+// wrapped in.
+type payload struct {
+	Op int             `json:"op"`
+	D  json.RawMessage `json:"d,omitempty"`
+	S  *int            `json:"s,omitempty"`
+	T  string          `json:"t,omitempty"`
+}
+
+// helloData is op 10's payload.
+type helloData struct {
+	HeartbeatInterval int `json:"heartbeat_interval"`
+}
+
+// identifyData is op 2's payload.
+type identifyData struct {
+	Token      string             `json:"token"`
+	Intents    int                `json:"intents"`
+	Properties identifyProperties `json:"properties"`
+}
+
+type identifyProperties struct {
+	OS      string `json:"os"`
+	Browser string `json:"browser"`
+	Device  string `json:"device"`
+}
+
+// resumeData is op 6's payload.
+type resumeData struct {
+	Token     string `json:"token"`
+	SessionID string `json:"session_id"`
+	Seq       int    `json:"seq"`
+}
+
+// readyData is the relevant subset of the READY dispatch's payload.
+type readyData struct {
+	SessionID        string `json:"session_id"`
+	ResumeGatewayURL string `json:"resume_gateway_url"`
+}
+
+// Event is one decoded Gateway dispatch (op 0), published on EventBus for
+// subscribers to filter by Type.
+type Event struct {
+	// Type is the dispatch's "t" field, e.g. "MESSAGE_CREATE",
+	// "INTERACTION_CREATE", "PRESENCE_UPDATE".
+	Type string
+	// Data is the dispatch's raw "d" payload; subscribers unmarshal it into
+	// whichever discordgo (or hand-rolled) struct matches Type.
+	Data json.RawMessage
+}
@@ -0,0 +1,62 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const apiBaseURL = "https://discord.com/api/v10"
+
+// RESTClient sends messages and typing indicators over Discord's REST API.
+// Gateway itself only ever reads events from its WebSocket; outbound
+// actions all go through the REST API instead, same as real Discord
+// clients.
+type RESTClient struct {
+	token  string
+	client *http.Client
+}
+
+// NewRESTClient creates a RESTClient authenticated as a bot.
+func NewRESTClient(token string) *RESTClient {
+	return &RESTClient{token: token, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// SendMessage posts content to channelID.
+func (r *RESTClient) SendMessage(ctx context.Context, channelID, content string) error {
+	body, err := json.Marshal(map[string]string{"content": content})
+	if err != nil {
+		return fmt.Errorf("marshal message: %w", err)
+	}
+	return r.do(ctx, http.MethodPost, fmt.Sprintf("/channels/%s/messages", channelID), body)
+}
+
+// Typing triggers channelID's typing indicator for about 10 seconds.
+func (r *RESTClient) Typing(ctx context.Context, channelID string) error {
+	return r.do(ctx, http.MethodPost, fmt.Sprintf("/channels/%s/typing", channelID), nil)
+}
+
+func (r *RESTClient) do(ctx context.Context, method, path string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, method, apiBaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bot "+r.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("discord API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
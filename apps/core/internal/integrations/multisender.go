@@ -0,0 +1,51 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// MultiSender fans a single notification out to every destination built
+// from its URLs, so a caller (or an operator setting NOTIFY_URLS) can add
+// or remove channels without touching code.
+type MultiSender struct {
+	targets []Integration
+}
+
+// NewMultiSender builds a MultiSender from a list of shoutrrr-style
+// notification URLs (see NewFromURL).
+func NewMultiSender(urls []string) (*MultiSender, error) {
+	targets := make([]Integration, 0, len(urls))
+	for _, rawURL := range urls {
+		target, err := NewFromURL(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("building notification target %q: %w", rawURL, err)
+		}
+		targets = append(targets, target)
+	}
+	return &MultiSender{targets: targets}, nil
+}
+
+// Send delivers payload to every target in parallel. Unlike errgroup's
+// usual first-error-wins behavior, every target is always attempted; any
+// failures are aggregated into a single error via errors.Join (nil if
+// every target succeeded).
+func (m *MultiSender) Send(ctx context.Context, payload interface{}) error {
+	var g errgroup.Group
+	errs := make([]error, len(m.targets))
+	for i, target := range m.targets {
+		i, target := i, target
+		g.Go(func() error {
+			if err := target.Send(ctx, payload); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", target.Name(), err)
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	return errors.Join(errs...)
+}
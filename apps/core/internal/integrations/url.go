@@ -0,0 +1,223 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultNotifyTimeout is used when a notification URL doesn't set
+// ?timeout=.
+const defaultNotifyTimeout = 10 * time.Second
+
+// NewFromURL builds a ready-to-use Integration from a shoutrrr-style
+// notification URL, so callers can configure destinations declaratively
+// (e.g. via a NOTIFY_URLS env var) instead of importing and constructing
+// each concrete adapter. Supported schemes:
+//
+//   - discord://token@webhook_id?username=...&avatar_url=...
+//   - slack://hooks.slack.com/services/T00/B00/xxx?channel=...
+//   - generic+https://example.com/webhook?header_X-Auth=abc&template=json
+//   - teams://group@tenant/altId/groupOwner?host=organization.webhook.office.com
+//
+// Any scheme accepts ?timeout= (a time.ParseDuration string) and
+// ?header_<Name>=<Value> query parameters, which are read into
+// WebhookConfig.Headers.
+func NewFromURL(rawURL string) (Integration, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse notification URL: %w", err)
+	}
+
+	timeout := defaultNotifyTimeout
+	if t := u.Query().Get("timeout"); t != "" {
+		d, err := time.ParseDuration(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", t, err)
+		}
+		timeout = d
+	}
+
+	switch {
+	case u.Scheme == "discord":
+		return newDiscordFromURL(u, timeout)
+	case u.Scheme == "slack":
+		return newSlackFromURL(u, timeout)
+	case u.Scheme == "teams":
+		return newTeamsFromURL(u, timeout)
+	case strings.HasPrefix(u.Scheme, "generic+"):
+		return newGenericFromURL(u, timeout)
+	default:
+		return nil, fmt.Errorf("unsupported notification URL scheme %q", u.Scheme)
+	}
+}
+
+// headersFromQuery reads ?header_<Name>=<Value> query parameters into a
+// header map, shared across every scheme's constructor.
+func headersFromQuery(q url.Values) map[string]string {
+	headers := make(map[string]string)
+	for key := range q {
+		if name, ok := strings.CutPrefix(key, "header_"); ok {
+			headers[name] = q.Get(key)
+		}
+	}
+	return headers
+}
+
+// newDiscordFromURL builds a DiscordAdapter from discord://token@webhook_id.
+func newDiscordFromURL(u *url.URL, timeout time.Duration) (Integration, error) {
+	if u.User == nil {
+		return nil, fmt.Errorf("discord URL must be discord://token@webhook_id")
+	}
+	token := u.User.Username()
+	id := u.Host
+	if token == "" || id == "" {
+		return nil, fmt.Errorf("discord URL must be discord://token@webhook_id")
+	}
+
+	webhookURL := fmt.Sprintf("https://discord.com/api/webhooks/%s/%s", id, token)
+	adapter := NewDiscordAdapter(webhookURL, timeout)
+
+	q := u.Query()
+	adapter.username = q.Get("username")
+	adapter.avatarURL = q.Get("avatar_url")
+	adapter.headers = headersFromQuery(q)
+
+	return adapter, nil
+}
+
+// newSlackFromURL builds a SlackAdapter from
+// slack://hooks.slack.com/services/T00/B00/xxx.
+func newSlackFromURL(u *url.URL, timeout time.Duration) (Integration, error) {
+	if u.Host == "" || u.Path == "" {
+		return nil, fmt.Errorf("slack URL must be slack://hooks.slack.com/services/...")
+	}
+
+	webhookURL := "https://" + u.Host + u.Path
+	adapter := NewSlackAdapter(webhookURL, timeout)
+
+	q := u.Query()
+	adapter.channel = q.Get("channel")
+	adapter.headers = headersFromQuery(q)
+
+	return adapter, nil
+}
+
+// GenericAdapter posts notifications to an arbitrary webhook URL built
+// from a generic+http(s):// notification URL.
+type GenericAdapter struct {
+	*Adapter
+	template string
+}
+
+// newGenericFromURL builds a GenericAdapter from
+// generic+https://example.com/webhook?header_X-Auth=abc&template=json.
+func newGenericFromURL(u *url.URL, timeout time.Duration) (Integration, error) {
+	realScheme, ok := strings.CutPrefix(u.Scheme, "generic+")
+	if !ok || realScheme == "" {
+		return nil, fmt.Errorf("generic URL must use generic+http or generic+https scheme")
+	}
+
+	target := *u
+	target.Scheme = realScheme
+
+	q := target.Query()
+	headers := headersFromQuery(q)
+	template := q.Get("template")
+	for key := range q {
+		if _, ok := strings.CutPrefix(key, "header_"); ok {
+			q.Del(key)
+		}
+	}
+	q.Del("template")
+	q.Del("timeout")
+	target.RawQuery = q.Encode()
+
+	adapter := NewAdapter(timeout)
+	adapter.RegisterWebhook("generic", WebhookConfig{
+		URL:     target.String(),
+		Headers: headers,
+		Timeout: timeout,
+	})
+
+	return &GenericAdapter{Adapter: adapter, template: template}, nil
+}
+
+// Name returns the integration name.
+func (g *GenericAdapter) Name() string { return "generic" }
+
+// Type returns the integration type.
+func (g *GenericAdapter) Type() string { return "webhook" }
+
+// Send posts payload to the generic webhook. A string payload is wrapped
+// as {"message": ...} when ?template=json was requested, or sent as a
+// bare JSON string otherwise; any other payload is sent as-is.
+func (g *GenericAdapter) Send(ctx context.Context, payload interface{}) error {
+	if text, ok := payload.(string); ok && g.template == "json" {
+		payload = map[string]string{"message": text}
+	}
+	_, err := g.SendWebhook(ctx, "generic", payload)
+	return err
+}
+
+// Validate always succeeds: a generic webhook imposes no payload shape.
+func (g *GenericAdapter) Validate(payload interface{}) error {
+	return nil
+}
+
+// TeamsAdapter posts MessageCard notifications to a Microsoft Teams
+// incoming webhook connector.
+type TeamsAdapter struct {
+	*Adapter
+}
+
+// newTeamsFromURL builds a TeamsAdapter from
+// teams://group@tenant/altId/groupOwner?host=organization.webhook.office.com.
+func newTeamsFromURL(u *url.URL, timeout time.Duration) (Integration, error) {
+	host := u.Query().Get("host")
+	if host == "" {
+		host = "outlook.office.com"
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if u.User == nil || u.Host == "" || len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("teams URL must be teams://group@tenant/altId/groupOwner")
+	}
+	group := u.User.Username()
+	tenant := u.Host
+	altID, groupOwner := parts[0], parts[1]
+
+	webhookURL := fmt.Sprintf("https://%s/webhookb2/%s@%s/IncomingWebhook/%s/%s", host, group, tenant, altID, groupOwner)
+	adapter := NewAdapter(timeout)
+	adapter.RegisterWebhook("teams", WebhookConfig{URL: webhookURL, Headers: headersFromQuery(u.Query()), Timeout: timeout})
+
+	return &TeamsAdapter{Adapter: adapter}, nil
+}
+
+// Name returns the integration name.
+func (t *TeamsAdapter) Name() string { return "teams" }
+
+// Type returns the integration type.
+func (t *TeamsAdapter) Type() string { return "webhook" }
+
+// Send posts a MessageCard to the Teams connector. payload is either a
+// plain string (wrapped as the card's text) or an already-built card.
+func (t *TeamsAdapter) Send(ctx context.Context, payload interface{}) error {
+	if text, ok := payload.(string); ok {
+		payload = map[string]interface{}{
+			"@type":    "MessageCard",
+			"@context": "http://schema.org/extensions",
+			"text":     text,
+		}
+	}
+	_, err := t.SendWebhook(ctx, "teams", payload)
+	return err
+}
+
+// Validate always succeeds: a MessageCard imposes no fixed payload shape
+// beyond what Send already builds.
+func (t *TeamsAdapter) Validate(payload interface{}) error {
+	return nil
+}
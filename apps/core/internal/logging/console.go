@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"sync"
+)
+
+// consoleHandler is a human-readable, single-line slog.Handler for local
+// development: "15:04:05.000 LEVEL message key=value ...", no braces or quoting
+// noise.
+type consoleHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	opts   slog.HandlerOptions
+	attrs  []slog.Attr
+	groups []string
+}
+
+func newConsoleHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	if opts == nil {
+		opts = &slog.HandlerOptions{}
+	}
+	return &consoleHandler{mu: &sync.Mutex{}, w: w, opts: *opts}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	min := h.opts.Level
+	if min == nil {
+		min = slog.LevelInfo
+	}
+	return level >= min.Level()
+}
+
+func (h *consoleHandler) Handle(_ context.Context, record slog.Record) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %-5s %s", record.Time.Format("15:04:05.000"), record.Level, record.Message)
+
+	if h.opts.AddSource && record.PC != 0 {
+		fmt.Fprintf(&buf, " source=%s", sourceLine(record.PC))
+	}
+
+	for _, attr := range h.attrs {
+		writeConsoleAttr(&buf, h.groups, attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		writeConsoleAttr(&buf, h.groups, attr)
+		return true
+	})
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func writeConsoleAttr(buf *bytes.Buffer, groups []string, attr slog.Attr) {
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+	buf.WriteByte(' ')
+	for _, g := range groups {
+		buf.WriteString(g)
+		buf.WriteByte('.')
+	}
+	fmt.Fprintf(buf, "%s=%v", attr.Key, attr.Value)
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &next
+}
+
+func (h *consoleHandler) WithGroup(name string) slog.Handler {
+	next := *h
+	next.groups = append(append([]string{}, h.groups...), name)
+	return &next
+}
+
+func sourceLine(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+}
@@ -0,0 +1,44 @@
+package logging
+
+import "context"
+
+type loggerCtxKey struct{}
+
+type traceCtxKey struct{}
+
+type traceInfo struct {
+	traceID string
+	spanID  string
+}
+
+// WithLogger attaches logger to ctx so it can be recovered with FromContext
+// by code (like gRPC interceptors) that doesn't have it threaded through
+// explicitly.
+func WithLogger(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, logger)
+}
+
+// FromContext returns the logger attached to ctx, or a bare "default"
+// service logger if none was attached.
+func FromContext(ctx context.Context) *Logger {
+	if logger, ok := ctx.Value(loggerCtxKey{}).(*Logger); ok {
+		return logger
+	}
+	return NewLogger("default")
+}
+
+// WithTraceContext attaches a trace/span ID pair to ctx so that Logger's
+// LogActivity/LogWorkflow/LogAPICall (via WithContext) and the OTel bridge
+// handler can tag log lines with the same IDs Jaeger shows for the request.
+func WithTraceContext(ctx context.Context, traceID, spanID string) context.Context {
+	return context.WithValue(ctx, traceCtxKey{}, traceInfo{traceID: traceID, spanID: spanID})
+}
+
+// TraceIDFromContext returns the trace/span ID attached to ctx, if any.
+func TraceIDFromContext(ctx context.Context) (traceID, spanID string, ok bool) {
+	info, ok := ctx.Value(traceCtxKey{}).(traceInfo)
+	if !ok || info.traceID == "" {
+		return "", "", false
+	}
+	return info.traceID, info.spanID, true
+}
@@ -2,6 +2,7 @@ package logging
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
 	"time"
@@ -12,25 +13,125 @@ type Logger struct {
 	*slog.Logger
 }
 
-// NewLogger creates a new structured logger.
+// LoggerConfig configures NewLoggerWithConfig. The zero value is not
+// directly usable for Output/Level; use DefaultLoggerConfig to get the
+// env-selected settings NewLogger itself uses.
+type LoggerConfig struct {
+	// Level is the minimum level that reaches Output.
+	Level slog.Level
+	// Format selects the handler: "json" (default), "text" or "console"
+	// (a human-readable single-line format for local development).
+	Format string
+	// AddSource adds the source file/line of each log call.
+	AddSource bool
+	// Output is where log lines are written. Defaults to os.Stdout; pass a
+	// *RotatingFileWriter for size/age-based rotation with compression.
+	Output io.Writer
+	// SampleRate, if > 0, caps Info/Debug records to this many per second
+	// via a token bucket; Warn/Error records are never sampled. Zero
+	// disables sampling.
+	SampleRate float64
+	// SampleBurst is the token bucket's burst capacity. Defaults to
+	// SampleRate when <= 0.
+	SampleBurst float64
+}
+
+// DefaultLoggerConfig returns the LoggerConfig NewLogger builds from
+// environment variables: level from LOG_LEVEL, format from LOG_FORMAT,
+// output to stdout, sampling disabled.
+func DefaultLoggerConfig() LoggerConfig {
+	return LoggerConfig{
+		Level:  levelFromEnv(),
+		Format: os.Getenv("LOG_FORMAT"),
+		Output: os.Stdout,
+	}
+}
+
+// NewLogger creates a new structured logger using DefaultLoggerConfig. The
+// handler is selected by the LOG_FORMAT environment variable ("json", the
+// default, "text" or "console"); the level is selected by LOG_LEVEL
+// ("debug", "info" (default), "warn", "error"). Use NewLoggerWithConfig to
+// customize output, sampling or source annotation.
 func NewLogger(service string) *Logger {
-	// Create JSON handler for production, text handler for development
-	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-		AddSource: false,
-	})
+	return NewLoggerWithConfig(service, DefaultLoggerConfig())
+}
+
+// NewLoggerWithConfig creates a structured logger from an explicit
+// LoggerConfig. Every logger built this way (and by NewLogger) has its
+// handler wrapped with NewOTelHandler, so trace_id/span_id are attached
+// automatically whenever a log call is made with a context carrying an
+// active OpenTelemetry span.
+func NewLoggerWithConfig(service string, cfg LoggerConfig) *Logger {
+	output := cfg.Output
+	if output == nil {
+		output = os.Stdout
+	}
 
-	logger := slog.New(handler)
-	logger = logger.With("service", service, "environment", "development")
+	var handler slog.Handler = newHandler(output, cfg.Format, &slog.HandlerOptions{
+		Level:     cfg.Level,
+		AddSource: cfg.AddSource,
+	})
+	handler = NewOTelHandler(handler)
+	if cfg.SampleRate > 0 {
+		handler = newSamplingHandler(handler, cfg.SampleRate, cfg.SampleBurst)
+	}
 
+	logger := slog.New(handler).With("service", service, "environment", envOrDefault())
 	return &Logger{logger}
 }
 
+// NewSlogLogger wraps an arbitrary slog.Handler, letting callers supply a
+// handler chain (e.g. NewOTelHandler in otel.go, or a test recorder) instead of
+// the env-selected default.
+func NewSlogLogger(handler slog.Handler) *Logger {
+	return &Logger{slog.New(handler)}
+}
+
+func newHandler(w io.Writer, format string, opts *slog.HandlerOptions) slog.Handler {
+	switch format {
+	case "text":
+		return slog.NewTextHandler(w, opts)
+	case "console":
+		return newConsoleHandler(w, opts)
+	default:
+		return slog.NewJSONHandler(w, opts)
+	}
+}
+
+func levelFromEnv() slog.Level {
+	switch os.Getenv("LOG_LEVEL") {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func envOrDefault() string {
+	if env := os.Getenv("ENVIRONMENT"); env != "" {
+		return env
+	}
+	return "development"
+}
+
 // With creates a new logger with additional context.
 func (l *Logger) With(args ...any) *Logger {
 	return &Logger{l.Logger.With(args...)}
 }
 
+// WithContext returns a logger that attributes subsequent log lines to the
+// trace carried by ctx, if any (see WithTraceContext).
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	if traceID, spanID, ok := TraceIDFromContext(ctx); ok {
+		return l.With("trace_id", traceID, "span_id", spanID)
+	}
+	return l
+}
+
 // Info logs an info message with structured context.
 func (l *Logger) Info(msg string, args ...any) {
 	l.Logger.Info(msg, args...)
@@ -52,9 +153,14 @@ func (l *Logger) Warn(msg string, args ...any) {
 	l.Logger.Warn(msg, args...)
 }
 
-// LogActivity logs activity execution metrics.
+// LogActivity logs activity execution metrics. It's attributed to ctx's
+// trace twice over: WithContext applies the explicit trace/span set via
+// WithTraceContext, if any, and InfoContext passes ctx to the handler chain
+// so NewOTelHandler can inject the active OpenTelemetry span's IDs even when
+// WithTraceContext was never called. Either way these line up with the
+// Jaeger view in debug.Handler.GetTraceDetails.
 func (l *Logger) LogActivity(ctx context.Context, activity string, duration time.Duration, success bool, args ...any) {
-	l.Logger.Info("activity completed",
+	l.WithContext(ctx).Logger.InfoContext(ctx, "activity completed",
 		append([]any{
 			"activity", activity,
 			"duration_ms", duration.Milliseconds(),
@@ -65,7 +171,7 @@ func (l *Logger) LogActivity(ctx context.Context, activity string, duration time
 
 // LogWorkflow logs workflow execution metrics.
 func (l *Logger) LogWorkflow(ctx context.Context, workflowID string, status string, args ...any) {
-	l.Logger.Info("workflow event",
+	l.WithContext(ctx).Logger.InfoContext(ctx, "workflow event",
 		append([]any{
 			"workflow_id", workflowID,
 			"status", status,
@@ -75,7 +181,7 @@ func (l *Logger) LogWorkflow(ctx context.Context, workflowID string, status stri
 
 // LogAPICall logs external API calls.
 func (l *Logger) LogAPICall(ctx context.Context, provider string, endpoint string, duration time.Duration, statusCode int, success bool) {
-	l.Logger.Info("api call",
+	l.WithContext(ctx).Logger.InfoContext(ctx, "api call",
 		"provider", provider,
 		"endpoint", endpoint,
 		"duration_ms", duration.Milliseconds(),
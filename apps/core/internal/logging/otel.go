@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelHandler wraps a slog.Handler and injects trace_id/span_id attributes
+// from the active OpenTelemetry span in ctx, so structured logs correlate
+// with the Jaeger traces surfaced by debug.Handler.GetTraceDetails.
+type otelHandler struct {
+	next slog.Handler
+}
+
+// NewOTelHandler wraps next so every record handled through it is enriched
+// with the trace/span ID of the span active in the record's context, when
+// one is present.
+func NewOTelHandler(next slog.Handler) slog.Handler {
+	return &otelHandler{next: next}
+}
+
+func (h *otelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *otelHandler) Handle(ctx context.Context, record slog.Record) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *otelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &otelHandler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *otelHandler) WithGroup(name string) slog.Handler {
+	return &otelHandler{next: h.next.WithGroup(name)}
+}
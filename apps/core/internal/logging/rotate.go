@@ -0,0 +1,153 @@
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// RotateConfig configures a RotatingFileWriter.
+type RotateConfig struct {
+	// Filename is the active log file's path; rotated segments are written
+	// alongside it with a timestamp suffix.
+	Filename string
+	// MaxSizeBytes rotates the file once it would exceed this size. Zero
+	// disables size-based rotation.
+	MaxSizeBytes int64
+	// MaxAge rotates the file once it's been open this long, regardless of
+	// size. Zero disables time-based rotation.
+	MaxAge time.Duration
+	// Compress gzips rotated segments (filename-timestamp -> filename-timestamp.gz)
+	// in the background after rotation.
+	Compress bool
+}
+
+// RotatingFileWriter is an io.Writer that rotates its underlying file by
+// size and/or age, similar to lumberjack. Old segments are renamed with a
+// timestamp suffix and, if Compress is set, gzipped in the background.
+type RotatingFileWriter struct {
+	cfg RotateConfig
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewRotatingFileWriter opens (creating if needed) cfg.Filename for
+// appending.
+func NewRotatingFileWriter(cfg RotateConfig) (*RotatingFileWriter, error) {
+	w := &RotatingFileWriter{cfg: cfg}
+	if err := w.openCurrent(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *RotatingFileWriter) openCurrent() error {
+	file, err := os.OpenFile(w.cfg.Filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file %s: %w", w.cfg.Filename, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file %s: %w", w.cfg.Filename, err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.openedAt = time.Now()
+	return nil
+}
+
+// Write implements io.Writer, rotating first if p would push the file past
+// MaxSizeBytes or the file has been open longer than MaxAge.
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *RotatingFileWriter) shouldRotate(nextWrite int) bool {
+	if w.cfg.MaxSizeBytes > 0 && w.size+int64(nextWrite) > w.cfg.MaxSizeBytes {
+		return true
+	}
+	if w.cfg.MaxAge > 0 && time.Since(w.openedAt) > w.cfg.MaxAge {
+		return true
+	}
+	return false
+}
+
+// rotate closes the current file, renames it to a timestamp-suffixed
+// segment, optionally compresses that segment in the background, and opens
+// a fresh file at cfg.Filename.
+func (w *RotatingFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file for rotation: %w", err)
+	}
+
+	rotated := fmt.Sprintf("%s.%s", w.cfg.Filename, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := os.Rename(w.cfg.Filename, rotated); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if w.cfg.Compress {
+		go compressSegment(rotated)
+	}
+
+	return w.openCurrent()
+}
+
+// compressSegment gzips path into path+".gz" and removes path, logging to
+// stderr on failure since the regular logger may itself be writing to this
+// rotator.
+func compressSegment(path string) {
+	if err := gzipFile(path); err != nil {
+		fmt.Fprintf(os.Stderr, "logging: failed to compress rotated segment %s: %v\n", path, err)
+	}
+}
+
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
+
+// Close closes the current file.
+func (w *RotatingFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
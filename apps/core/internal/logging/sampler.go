@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// TokenBucket is a simple rate limiter: it holds up to capacity tokens,
+// refilled at refillPerSec tokens/second, and grants one token per Allow
+// call that finds the bucket non-empty.
+type TokenBucket struct {
+	mu           sync.Mutex
+	capacity     float64
+	refillPerSec float64
+	tokens       float64
+	last         time.Time
+}
+
+// NewTokenBucket creates a TokenBucket with the given capacity and refill
+// rate (tokens/second). It starts full.
+func NewTokenBucket(capacity, refillPerSec float64) *TokenBucket {
+	return &TokenBucket{
+		capacity:     capacity,
+		refillPerSec: refillPerSec,
+		tokens:       capacity,
+		last:         time.Now(),
+	}
+}
+
+// Allow reports whether a token is available, consuming one if so.
+func (b *TokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+
+	b.tokens += elapsed * b.refillPerSec
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// samplingHandler is a tail-sampling slog.Handler: Warn and Error records
+// always pass through, but Info and Debug records are admitted only while
+// bucket has tokens, so a burst of routine logging can't drown out (or
+// overwhelm) a downstream log pipeline.
+type samplingHandler struct {
+	next   slog.Handler
+	bucket *TokenBucket
+}
+
+// newSamplingHandler wraps next with a tail-sampling TokenBucket sized
+// ratePerSec tokens/second and burst capacity (ratePerSec if burst <= 0).
+func newSamplingHandler(next slog.Handler, ratePerSec, burst float64) slog.Handler {
+	if burst <= 0 {
+		burst = ratePerSec
+	}
+	return &samplingHandler{next: next, bucket: NewTokenBucket(burst, ratePerSec)}
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelWarn || h.bucket.Allow() {
+		return h.next.Handle(ctx, record)
+	}
+	return nil
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{next: h.next.WithAttrs(attrs), bucket: h.bucket}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{next: h.next.WithGroup(name), bucket: h.bucket}
+}
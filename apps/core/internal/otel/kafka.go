@@ -0,0 +1,53 @@
+package otel
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+)
+
+// kafkaHeaderCarrier adapts a *[]kafka.Header to propagation.TextMapCarrier
+// so trace context can travel to consumers in Kafka message headers.
+type kafkaHeaderCarrier struct {
+	headers *[]kafka.Header
+}
+
+func (c kafkaHeaderCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c kafkaHeaderCarrier) Set(key, value string) {
+	for i, h := range *c.headers {
+		if h.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c kafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, len(*c.headers))
+	for i, h := range *c.headers {
+		keys[i] = h.Key
+	}
+	return keys
+}
+
+// InjectKafkaHeaders writes ctx's trace context into headers so whatever
+// consumes the message can continue the same trace.
+func InjectKafkaHeaders(ctx context.Context, headers *[]kafka.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, kafkaHeaderCarrier{headers: headers})
+}
+
+// ExtractKafkaHeaders returns a context carrying the trace context encoded
+// in headers, if the producer set one.
+func ExtractKafkaHeaders(ctx context.Context, headers []kafka.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, kafkaHeaderCarrier{headers: &headers})
+}
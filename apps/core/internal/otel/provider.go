@@ -0,0 +1,84 @@
+// Package otel wires up distributed tracing for IterateSwarm: a global
+// TracerProvider exporting to an OTLP collector, a W3C trace-context
+// propagator, and helpers so the Fiber HTTP layer, the Kafka producer/
+// consumer in redpanda, and the Temporal workflow/activity layer all emit
+// spans into the same trace tree that debug.CorrelateTraceID reads back.
+package otel
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Shutdown flushes any buffered spans and stops the TracerProvider it was
+// returned from. Callers should defer it at startup.
+type Shutdown func(context.Context) error
+
+// InitTracerProvider configures the global TracerProvider and text-map
+// propagator for serviceName, exporting spans over OTLP to the endpoint in
+// OTEL_EXPORTER_OTLP_ENDPOINT (default "localhost:4317"). The exporter
+// protocol is selected by OTEL_EXPORTER_OTLP_PROTOCOL ("grpc", the
+// default, or "http/protobuf"), and the sampling strategy by
+// OTEL_TRACES_SAMPLER / OTEL_TRACES_SAMPLER_ARG (see NewSampler).
+func InitTracerProvider(ctx context.Context, serviceName string) (Shutdown, error) {
+	exporter, err := newExporter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	sampler, err := NewSampler(os.Getenv("OTEL_TRACES_SAMPLER"), os.Getenv("OTEL_TRACES_SAMPLER_ARG"))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := sdkresource.New(ctx,
+		sdkresource.WithAttributes(semconv.ServiceName(serviceName)),
+		sdkresource.WithFromEnv(),
+		sdkresource.WithHost(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return tp.Shutdown, nil
+}
+
+func newExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "localhost:4317"
+	}
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL") == "http/protobuf" {
+		return otlptracehttp.New(ctx,
+			otlptracehttp.WithEndpoint(endpoint),
+			otlptracehttp.WithInsecure(),
+		)
+	}
+
+	return otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(endpoint),
+		otlptracegrpc.WithInsecure(),
+	)
+}
@@ -0,0 +1,99 @@
+package otel
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewSampler builds the sdktrace.Sampler selected by kind: "const" (the
+// default when kind is empty), "probabilistic", or "ratelimiting". Each
+// interprets arg differently:
+//   - "const": "0" samples nothing, anything else (default "1") samples everything.
+//   - "probabilistic": a ratio in [0,1] of traces to sample (default 1).
+//   - "ratelimiting": a maximum number of newly-started traces to sample per second (default 100).
+//
+// All three are parent-based: a span with a sampled parent is always sampled.
+func NewSampler(kind, arg string) (sdktrace.Sampler, error) {
+	switch kind {
+	case "", "const":
+		if arg == "0" {
+			return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+		}
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+
+	case "probabilistic":
+		ratio := 1.0
+		if arg != "" {
+			parsed, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG %q: %w", arg, err)
+			}
+			ratio = parsed
+		}
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio)), nil
+
+	case "ratelimiting":
+		rate := 100.0
+		if arg != "" {
+			parsed, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG %q: %w", arg, err)
+			}
+			rate = parsed
+		}
+		return sdktrace.ParentBased(newRateLimitingSampler(rate)), nil
+
+	default:
+		return nil, fmt.Errorf("unknown OTEL_TRACES_SAMPLER: %s", kind)
+	}
+}
+
+// rateLimitingSampler admits up to maxPerSecond new traces per second using
+// a token bucket refilled continuously; the OTel SDK ships const and
+// probabilistic samplers but no rate-limiting one.
+type rateLimitingSampler struct {
+	mu           sync.Mutex
+	maxPerSecond float64
+	tokens       float64
+	lastRefill   time.Time
+}
+
+func newRateLimitingSampler(maxPerSecond float64) *rateLimitingSampler {
+	return &rateLimitingSampler{
+		maxPerSecond: maxPerSecond,
+		tokens:       maxPerSecond,
+		lastRefill:   time.Now(),
+	}
+}
+
+func (s *rateLimitingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.tokens += now.Sub(s.lastRefill).Seconds() * s.maxPerSecond
+	if s.tokens > s.maxPerSecond {
+		s.tokens = s.maxPerSecond
+	}
+	s.lastRefill = now
+
+	decision := sdktrace.Drop
+	if s.tokens >= 1 {
+		s.tokens--
+		decision = sdktrace.RecordAndSample
+	}
+
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (s *rateLimitingSampler) Description() string {
+	return fmt.Sprintf("RateLimitingSampler{%g tps}", s.maxPerSecond)
+}
@@ -0,0 +1,22 @@
+package otel
+
+import (
+	"go.opentelemetry.io/otel"
+	temporalotel "go.temporal.io/sdk/contrib/opentelemetry"
+	"go.temporal.io/sdk/interceptor"
+)
+
+// NewTemporalInterceptor builds the Temporal tracing interceptor: it starts
+// a span per workflow and activity execution, and carries the W3C
+// traceparent through the workflow's Temporal header so a trace started by
+// an HTTP request or a Kafka consume continues across FeedbackWorkflow and
+// its activities. The returned value implements both
+// interceptor.ClientInterceptor (register on temporal.Client) and
+// interceptor.WorkerInterceptor (register on the worker.Worker), so the
+// same instance is used on both sides.
+func NewTemporalInterceptor() (interceptor.Interceptor, error) {
+	return temporalotel.NewTracingInterceptor(temporalotel.TracerOptions{
+		Tracer:            otel.Tracer("iterateswarm-core/temporal"),
+		TextMapPropagator: otel.GetTextMapPropagator(),
+	})
+}
@@ -0,0 +1,12 @@
+package otel
+
+import (
+	"go.opentelemetry.io/otel"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Tracer returns the named tracer from the global TracerProvider set by
+// InitTracerProvider (or a no-op tracer if it hasn't been called).
+func Tracer(name string) oteltrace.Tracer {
+	return otel.Tracer(name)
+}
@@ -0,0 +1,239 @@
+// Package ratelimit wraps an *http.Client with token-bucket rate limiting
+// for outbound integration webhooks (Discord, Slack, and generic webhook
+// targets), honoring the rate limit headers Discord documents and falling
+// back to Retry-After for targets that don't send bucket headers at all.
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Error is returned by Client.Do alongside the (non-2xx) response when a
+// request was rejected with HTTP 429. Callers that want to retry rather
+// than surface the failure can inspect RetryAfter/Global directly, and
+// retry.RetryConfig recognizes this type to replace its exponential
+// backoff with RetryAfter.
+type Error struct {
+	RetryAfter time.Duration
+	Global     bool
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rate limited: retry after %s (global=%v)", e.RetryAfter, e.Global)
+}
+
+// majorParamRe picks out Discord's "major parameter" resources, which each
+// get their own independent bucket regardless of which exact route is hit.
+var majorParamRe = regexp.MustCompile(`/(channels|guilds|webhooks)/(\d+)`)
+
+// idSegmentRe templates out non-major numeric IDs (e.g. a message ID in
+// DELETE /channels/{id}/messages/{message.id}) so routes that only differ
+// by such an ID still collapse into the same bucket.
+var idSegmentRe = regexp.MustCompile(`/\d{15,20}`)
+
+// routeKey derives a fallback bucket key from method+path, used until the
+// server's own X-RateLimit-Bucket hash is observed for that route.
+func routeKey(method, path string) string {
+	major := ""
+	if m := majorParamRe.FindStringSubmatch(path); m != nil {
+		major = m[1] + ":" + m[2]
+	}
+	templated := idSegmentRe.ReplaceAllString(path, "/{id}")
+	return method + " " + templated + "#" + major
+}
+
+// bucket tracks one rate-limit bucket's remaining tokens and next reset
+// instant. A freshly created bucket starts with one token so the first
+// request through it is never blocked on unknown state.
+type bucket struct {
+	mu        sync.Mutex
+	remaining int
+	resetAt   time.Time
+}
+
+func newBucket() *bucket {
+	return &bucket{remaining: 1}
+}
+
+// Wait blocks until the bucket has a token available or its reset has
+// passed, or ctx is done.
+func (b *bucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		remaining := b.remaining
+		resetAt := b.resetAt
+		b.mu.Unlock()
+
+		if remaining > 0 || resetAt.IsZero() || time.Now().After(resetAt) {
+			return nil
+		}
+
+		timer := time.NewTimer(time.Until(resetAt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (b *bucket) updateFromHeaders(h http.Header) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if remaining := h.Get("X-RateLimit-Remaining"); remaining != "" {
+		if n, err := strconv.Atoi(remaining); err == nil {
+			b.remaining = n
+		}
+	}
+	if resetAfter := h.Get("X-RateLimit-Reset-After"); resetAfter != "" {
+		if secs, err := strconv.ParseFloat(resetAfter, 64); err == nil {
+			b.resetAt = time.Now().Add(time.Duration(secs * float64(time.Second)))
+		}
+	}
+}
+
+// blockFor forces the bucket closed until d has elapsed, as happens on a
+// 429 response.
+func (b *bucket) blockFor(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remaining = 0
+	b.resetAt = time.Now().Add(d)
+}
+
+// Client rate-limits requests made through an underlying *http.Client,
+// keyed per route (with a single global bucket for HTTP 429 responses
+// carrying a global flag). The zero value is not usable; use NewClient.
+type Client struct {
+	http *http.Client
+
+	mu            sync.Mutex
+	buckets       map[string]*bucket
+	routeToBucket map[string]string
+	global        *bucket
+}
+
+// NewClient wraps httpClient (or a default 10s-timeout client if nil) with
+// rate limiting.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{
+		http:          httpClient,
+		buckets:       make(map[string]*bucket),
+		routeToBucket: make(map[string]string),
+		global:        newBucket(),
+	}
+}
+
+func (c *Client) bucketKeyFor(route string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if canonical, ok := c.routeToBucket[route]; ok {
+		return canonical
+	}
+	return route
+}
+
+func (c *Client) bucketFor(key string) *bucket {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.buckets[key]
+	if !ok {
+		b = newBucket()
+		c.buckets[key] = b
+	}
+	return b
+}
+
+// recordBucketHash remembers that route resolved to the server's own hash,
+// so future requests on that route share the same bucket state even if
+// routeKey would otherwise have templated them differently.
+func (c *Client) recordBucketHash(route, hash string, b *bucket) {
+	if hash == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.routeToBucket[route] = hash
+	if _, exists := c.buckets[hash]; !exists {
+		c.buckets[hash] = b
+	}
+}
+
+// Do waits for the route's bucket (and the global bucket) to have capacity,
+// then issues req. On HTTP 429 it updates the appropriate bucket from the
+// response and returns the response alongside a *Error describing how long
+// to wait, so callers that want to retry can do so without re-deriving
+// Retry-After themselves.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if err := c.global.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("waiting on global rate limit: %w", err)
+	}
+
+	route := routeKey(req.Method, req.URL.Path)
+	key := c.bucketKeyFor(route)
+	b := c.bucketFor(key)
+	if err := b.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("waiting on rate limit bucket %q: %w", key, err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	b.updateFromHeaders(resp.Header)
+	c.recordBucketHash(route, resp.Header.Get("X-RateLimit-Bucket"), b)
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, global := parse429(resp)
+		if global {
+			c.global.blockFor(retryAfter)
+		} else {
+			b.blockFor(retryAfter)
+		}
+		return resp, &Error{RetryAfter: retryAfter, Global: global}
+	}
+
+	return resp, nil
+}
+
+// parse429 reads Retry-After/global from a 429 response. Discord sends a
+// JSON body with retry_after (seconds, float) and global; other webhook
+// targets (Slack) typically only send the Retry-After header, so that's
+// the fallback.
+func parse429(resp *http.Response) (retryAfter time.Duration, global bool) {
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var payload struct {
+		RetryAfter float64 `json:"retry_after"`
+		Global     bool    `json:"global"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.RetryAfter > 0 {
+		return time.Duration(payload.RetryAfter * float64(time.Second)), payload.Global
+	}
+
+	if h := resp.Header.Get("Retry-After"); h != "" {
+		if secs, err := strconv.ParseFloat(h, 64); err == nil {
+			return time.Duration(secs * float64(time.Second)), resp.Header.Get("X-RateLimit-Global") == "true"
+		}
+	}
+
+	return time.Second, false
+}
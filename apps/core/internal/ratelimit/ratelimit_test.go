@@ -0,0 +1,147 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRouteKeyTemplatesMajorParamsAndIDs(t *testing.T) {
+	got := routeKey("POST", "/channels/123456789012345678/messages/987654321098765432")
+	want := "POST /channels/{id}/messages/{id}#channels:123456789012345678"
+	if got != want {
+		t.Fatalf("routeKey() = %q, want %q", got, want)
+	}
+
+	if got := routeKey("GET", "/some/other/path"); got != "GET /some/other/path#" {
+		t.Fatalf("routeKey() with no major param = %q", got)
+	}
+}
+
+func TestBucketWaitBlocksUntilReset(t *testing.T) {
+	b := newBucket()
+	b.blockFor(50 * time.Millisecond)
+
+	start := time.Now()
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("Wait returned after %s, expected at least 50ms", elapsed)
+	}
+}
+
+func TestBucketWaitReturnsImmediatelyWithTokens(t *testing.T) {
+	b := newBucket()
+	start := time.Now()
+	if err := b.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Millisecond {
+		t.Fatalf("Wait with tokens available took %s, expected near-instant", elapsed)
+	}
+}
+
+func TestBucketWaitRespectsContextCancellation(t *testing.T) {
+	b := newBucket()
+	b.blockFor(time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := b.Wait(ctx); err == nil {
+		t.Fatal("expected context deadline error, got nil")
+	}
+}
+
+func TestClientDoReturnsErrorOnDiscord429(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Bucket", "test-bucket")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"retry_after":0.05,"global":false}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(nil)
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/channels/123456789012345678/messages", nil)
+
+	resp, err := c.Do(req)
+	if resp == nil {
+		t.Fatal("expected a non-nil response alongside the rate limit error")
+	}
+	defer resp.Body.Close()
+
+	var rlErr *Error
+	if err == nil {
+		t.Fatal("expected a rate limit error, got nil")
+	}
+	if rlErr, _ = err.(*Error); rlErr == nil {
+		t.Fatalf("expected *Error, got %T: %v", err, err)
+	}
+	if rlErr.Global {
+		t.Fatal("expected a non-global rate limit")
+	}
+	if rlErr.RetryAfter != 50*time.Millisecond {
+		t.Fatalf("RetryAfter = %s, want 50ms", rlErr.RetryAfter)
+	}
+}
+
+func TestClientDoBlocksOnGlobalRateLimitThenSucceeds(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.Header().Set("X-RateLimit-Global", "true")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient(nil)
+
+	req1, _ := http.NewRequest(http.MethodGet, srv.URL+"/webhooks/1/abc", nil)
+	resp1, err := c.Do(req1)
+	if resp1 != nil {
+		resp1.Body.Close()
+	}
+	var rlErr *Error
+	if err == nil {
+		t.Fatal("expected the first request to be rate limited")
+	}
+	if rlErr, _ = err.(*Error); rlErr == nil || !rlErr.Global {
+		t.Fatalf("expected a global rate limit error, got %v", err)
+	}
+
+	req2, _ := http.NewRequest(http.MethodGet, srv.URL+"/webhooks/1/abc", nil)
+	resp2, err := c.Do(req2)
+	if err != nil {
+		t.Fatalf("unexpected error on second request: %v", err)
+	}
+	defer resp2.Body.Close()
+	if resp2.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp2.StatusCode)
+	}
+}
+
+func TestParse429FallsBackToRetryAfterHeader(t *testing.T) {
+	resp := &http.Response{
+		Header: http.Header{
+			"Retry-After":        []string{"2"},
+			"X-RateLimit-Global": []string{"true"},
+		},
+		Body: http.NoBody,
+	}
+
+	retryAfter, global := parse429(resp)
+	if retryAfter != 2*time.Second {
+		t.Fatalf("retryAfter = %s, want 2s", retryAfter)
+	}
+	if !global {
+		t.Fatal("expected global=true from X-RateLimit-Global header")
+	}
+}
@@ -2,33 +2,72 @@ package redpanda
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"iterateswarm-core/internal/otel"
 )
 
+var tracer = otel.Tracer("iterateswarm-core/redpanda")
+
 // Client wraps the Kafka client.
 type Client struct {
-	writer *kafka.Writer
-	reader *kafka.Reader
-	topic  string
+	writer  *kafka.Writer
+	reader  *kafka.Reader
+	topic   string
+	brokers []string
+	dialer  *kafka.Dialer
+	ctx     context.Context
 }
 
-// NewClient creates a new Kafka client.
-func NewClient(brokers []string, topic string) (*Client, error) {
-	log.Printf("Connecting to Kafka at %v", brokers)
+// NewClient creates a new Kafka client with a plaintext, unauthenticated
+// connection. Use NewClientWithConfig for TLS and SASL/SCRAM/OAUTHBEARER.
+// ctx bounds the initial broker/controller dial used for topic creation and
+// is retained for Close, so cancelling it (e.g. on SIGTERM) lets the caller
+// shut the client down deterministically.
+func NewClient(ctx context.Context, brokers []string, topic string) (*Client, error) {
+	return NewClientWithConfig(ctx, DefaultClientConfig(brokers, topic))
+}
+
+// NewClientWithConfig creates a Kafka client using cfg's TLS and SASL
+// settings for both the reader/writer and the controller connection used
+// during topic creation.
+func NewClientWithConfig(ctx context.Context, cfg ClientConfig) (*Client, error) {
+	brokers, topic := cfg.Brokers, cfg.Topic
+	log.Printf("Connecting to Kafka at %v (tls=%t, sasl=%s)", brokers, cfg.TLS != nil, cfg.SASLMechanism)
+
+	mechanism, err := cfg.saslMechanism()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build SASL mechanism: %w", err)
+	}
+
+	dialer := &kafka.Dialer{
+		Timeout:       10 * time.Second,
+		DualStack:     true,
+		TLS:           cfg.TLS,
+		SASLMechanism: mechanism,
+	}
 
 	writer := &kafka.Writer{
 		Addr:         kafka.TCP(brokers...),
 		Topic:        topic,
 		Balancer:     &kafka.LeastBytes{},
 		BatchTimeout: 10 * time.Millisecond,
+		Transport: &kafka.Transport{
+			TLS:  cfg.TLS,
+			SASL: mechanism,
+		},
 	}
 
 	// Ensure topic exists by creating it if needed
-	conn, err := kafka.Dial("tcp", brokers[0])
+	conn, err := dialer.DialContext(ctx, "tcp", brokers[0])
 	if err != nil {
 		log.Printf("Warning: Could not connect to Kafka: %v", err)
 	} else {
@@ -36,7 +75,7 @@ func NewClient(brokers []string, topic string) (*Client, error) {
 		if err != nil {
 			log.Printf("Warning: Could not get controller: %v", err)
 		} else {
-			controllerConn, err := kafka.Dial("tcp", fmt.Sprintf("%s:%d", controller.Host, controller.Port))
+			controllerConn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", controller.Host, controller.Port))
 			if err != nil {
 				log.Printf("Warning: Could not connect to controller: %v", err)
 			} else {
@@ -63,48 +102,53 @@ func NewClient(brokers []string, topic string) (*Client, error) {
 		GroupID:  "iterateswarm-consumer",
 		MinBytes: 10e3, // 10KB
 		MaxBytes: 10e6, // 10MB
+		Dialer:   dialer,
 	})
 
 	return &Client{
-		writer: writer,
-		reader: reader,
-		topic:  topic,
+		writer:  writer,
+		reader:  reader,
+		topic:   topic,
+		brokers: brokers,
+		dialer:  dialer,
+		ctx:     ctx,
 	}, nil
 }
 
 // Publish sends a message to the configured topic.
 func (c *Client) Publish(value []byte) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	msg := kafka.Message{
-		Value: value,
-		Key:   []byte(time.Now().Format(time.RFC3339)),
-	}
-
-	err := c.writer.WriteMessages(ctx, msg)
-	if err != nil {
-		log.Printf("Failed to publish message: %v", err)
-		return err
-	}
-
-	log.Printf("Message published to %s", c.topic)
-	return nil
+	return c.publish(context.Background(), c.topic, value)
 }
 
 // PublishToTopic sends a message to a specific topic (overrides configured topic).
 func (c *Client) PublishToTopic(topic string, value []byte) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	return c.publish(context.Background(), topic, value)
+}
+
+// publish creates a producer span for topic, injects its trace context
+// into the message headers so Consume can continue it, and writes value.
+func (c *Client) publish(ctx context.Context, topic string, value []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	ctx, span := tracer.Start(ctx, fmt.Sprintf("%s publish", topic), oteltrace.WithSpanKind(oteltrace.SpanKindProducer),
+		oteltrace.WithAttributes(
+			semconv.MessagingSystem("kafka"),
+			semconv.MessagingDestinationName(topic),
+			attribute.String("messaging.operation", "publish"),
+		),
+	)
+	defer span.End()
+
 	msg := kafka.Message{
 		Topic: topic,
 		Value: value,
 		Key:   []byte(time.Now().Format(time.RFC3339)),
 	}
+	otel.InjectKafkaHeaders(ctx, &msg.Headers)
 
-	err := c.writer.WriteMessages(ctx, msg)
-	if err != nil {
+	if err := c.writer.WriteMessages(ctx, msg); err != nil {
+		span.RecordError(err)
 		log.Printf("Failed to publish message: %v", err)
 		return err
 	}
@@ -118,7 +162,9 @@ func (c *Client) PublishFeedback(data []byte) error {
 	return c.Publish(data)
 }
 
-// Consume consumes messages from a topic.
+// Consume consumes messages from a topic. Each message is consumed inside a
+// span that continues the trace the producer injected into its headers, so
+// debug.CorrelateTraceID can stitch publish and consume together.
 func (c *Client) Consume(ctx context.Context, topic string) <-chan kafka.Message {
 	records := make(chan kafka.Message, 100)
 
@@ -138,6 +184,17 @@ func (c *Client) Consume(ctx context.Context, topic string) <-chan kafka.Message
 					log.Printf("Consumer error: %v", err)
 					continue
 				}
+
+				msgCtx := otel.ExtractKafkaHeaders(ctx, msg.Headers)
+				_, span := tracer.Start(msgCtx, fmt.Sprintf("%s consume", topic), oteltrace.WithSpanKind(oteltrace.SpanKindConsumer),
+					oteltrace.WithAttributes(
+						semconv.MessagingSystem("kafka"),
+						semconv.MessagingDestinationName(topic),
+						attribute.String("messaging.operation", "consume"),
+					),
+				)
+				span.End()
+
 				records <- msg
 			}
 		}
@@ -146,20 +203,44 @@ func (c *Client) Consume(ctx context.Context, topic string) <-chan kafka.Message
 	return records
 }
 
-// Close closes the client.
-func (c *Client) Close() error {
+// Close closes the client. Callers should cancel the context passed to
+// NewClient/NewClientWithConfig before calling Close, so any in-flight
+// ConsumeWorkers call has already returned and isn't racing the reader/
+// writer teardown; ctx itself only bounds this call's own work.
+func (c *Client) Close(ctx context.Context) error {
+	var errs []error
 	if c.reader != nil {
-		c.reader.Close()
+		if err := c.reader.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 	if c.writer != nil {
-		return c.writer.Close()
+		if err := c.writer.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
-	return nil
+	return errors.Join(errs...)
+}
+
+// Brokers returns the broker addresses this client was configured with, so
+// callers needing their own kafka-go Client (e.g. debug's admin subsystem)
+// don't have to duplicate connection configuration.
+func (c *Client) Brokers() []string {
+	return c.brokers
+}
+
+// Dialer returns the Dialer (including TLS/SASL) this client was configured
+// with.
+func (c *Client) Dialer() *kafka.Dialer {
+	return c.dialer
 }
 
 // Health checks if the client is healthy.
 func (c *Client) Health(ctx context.Context) error {
-	conn, err := kafka.Dial("tcp", "localhost:19092")
+	if len(c.brokers) == 0 {
+		return fmt.Errorf("no brokers configured")
+	}
+	conn, err := c.dialer.DialContext(ctx, "tcp", c.brokers[0])
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,262 @@
+package redpanda
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.temporal.io/sdk/client"
+)
+
+// JobDefinition describes a subscription a caller registers against a
+// topic: messages are filtered by Filter and, when they match, delivered
+// either over HTTP or by starting a Temporal workflow.
+type JobDefinition struct {
+	ID string `json:"id"`
+	// Topic is the Kafka topic to consume from.
+	Topic string `json:"topic"`
+	// Filter is a flat JSON predicate: every key/value pair in it must
+	// equal the corresponding field decoded from the message value for the
+	// message to match. An empty/nil filter matches every message.
+	Filter map[string]interface{} `json:"filter,omitempty"`
+	// DeliveryURI is either an "http(s)://" endpoint to POST matching
+	// messages to, or "temporal://<task-queue>/<workflow-type>" to start a
+	// Temporal workflow per match.
+	DeliveryURI string `json:"delivery_uri"`
+	// Compression is "gzip" when record values are gzip-compressed, or ""
+	// for raw payloads.
+	Compression string `json:"compression,omitempty"`
+}
+
+// JobStats reports the running counters for a registered job.
+type JobStats struct {
+	ID        string `json:"id"`
+	Topic     string `json:"topic"`
+	Processed int64  `json:"processed"`
+	Matched   int64  `json:"matched"`
+	Dropped   int64  `json:"dropped"`
+	Lag       int64  `json:"lag"`
+}
+
+// job is the running state behind a JobDefinition.
+type job struct {
+	def       JobDefinition
+	reader    *kafka.Reader
+	cancel    context.CancelFunc
+	processed atomic.Int64
+	matched   atomic.Int64
+	dropped   atomic.Int64
+}
+
+// JobManager runs filter-driven Kafka consumers that dispatch matching
+// messages to an HTTP endpoint or a Temporal workflow.
+type JobManager struct {
+	brokers        []string
+	temporalClient client.Client
+	httpClient     *http.Client
+
+	mu   sync.RWMutex
+	jobs map[string]*job
+}
+
+// NewJobManager creates a JobManager reading from brokers. temporalClient
+// may be nil if no job in this process will use a "temporal://" delivery URI.
+func NewJobManager(brokers []string, temporalClient client.Client) *JobManager {
+	return &JobManager{
+		brokers:        brokers,
+		temporalClient: temporalClient,
+		httpClient:     &http.Client{Timeout: 10 * time.Second},
+		jobs:           make(map[string]*job),
+	}
+}
+
+// RegisterJob starts a consumer goroutine for def and returns its ID
+// (def.ID is generated if empty).
+func (m *JobManager) RegisterJob(ctx context.Context, def JobDefinition) (string, error) {
+	if def.Topic == "" {
+		return "", fmt.Errorf("redpanda: job definition requires a topic")
+	}
+	if def.DeliveryURI == "" {
+		return "", fmt.Errorf("redpanda: job definition requires a delivery_uri")
+	}
+	if def.ID == "" {
+		def.ID = fmt.Sprintf("job-%s-%d", def.Topic, time.Now().UnixNano())
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  m.brokers,
+		Topic:    def.Topic,
+		GroupID:  "redpanda-job-" + def.ID,
+		MinBytes: 1,
+		MaxBytes: 10e6,
+	})
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	j := &job{def: def, reader: reader, cancel: cancel}
+
+	m.mu.Lock()
+	m.jobs[def.ID] = j
+	m.mu.Unlock()
+
+	go m.run(jobCtx, j)
+
+	return def.ID, nil
+}
+
+// StopJob cancels the consumer goroutine for jobID and closes its reader.
+func (m *JobManager) StopJob(jobID string) error {
+	m.mu.Lock()
+	j, ok := m.jobs[jobID]
+	delete(m.jobs, jobID)
+	m.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("redpanda: unknown job %q", jobID)
+	}
+	j.cancel()
+	return j.reader.Close()
+}
+
+// Jobs returns the current stats for every registered job.
+func (m *JobManager) Jobs() []JobStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stats := make([]JobStats, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		lag := int64(0)
+		if l := j.reader.Lag(); l > 0 {
+			lag = l
+		}
+		stats = append(stats, JobStats{
+			ID:        j.def.ID,
+			Topic:     j.def.Topic,
+			Processed: j.processed.Load(),
+			Matched:   j.matched.Load(),
+			Dropped:   j.dropped.Load(),
+			Lag:       lag,
+		})
+	}
+	return stats
+}
+
+func (m *JobManager) run(ctx context.Context, j *job) {
+	defer j.reader.Close()
+
+	for {
+		msg, err := j.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("redpanda job %s: fetch error: %v", j.def.ID, err)
+			continue
+		}
+		j.processed.Add(1)
+
+		value := msg.Value
+		if j.def.Compression == "gzip" {
+			decompressed, err := gunzip(value)
+			if err != nil {
+				log.Printf("redpanda job %s: failed to decompress message: %v", j.def.ID, err)
+				j.dropped.Add(1)
+				continue
+			}
+			value = decompressed
+		}
+
+		matched, event := evaluateFilter(value, j.def.Filter)
+		if !matched {
+			continue
+		}
+		j.matched.Add(1)
+
+		if err := m.dispatch(ctx, j.def, event, value); err != nil {
+			log.Printf("redpanda job %s: dispatch failed: %v", j.def.ID, err)
+			j.dropped.Add(1)
+		}
+	}
+}
+
+func gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// evaluateFilter decodes value as JSON and reports whether every key/value
+// pair in filter is present and equal in it.
+func evaluateFilter(value []byte, filter map[string]interface{}) (bool, map[string]interface{}) {
+	if len(filter) == 0 {
+		return true, nil
+	}
+
+	var event map[string]interface{}
+	if err := json.Unmarshal(value, &event); err != nil {
+		return false, nil
+	}
+
+	for key, want := range filter {
+		got, ok := event[key]
+		if !ok || fmt.Sprintf("%v", got) != fmt.Sprintf("%v", want) {
+			return false, nil
+		}
+	}
+
+	return true, event
+}
+
+func (m *JobManager) dispatch(ctx context.Context, def JobDefinition, event map[string]interface{}, raw []byte) error {
+	if strings.HasPrefix(def.DeliveryURI, "temporal://") {
+		return m.dispatchTemporal(ctx, def, event)
+	}
+	return m.dispatchHTTP(ctx, def, raw)
+}
+
+func (m *JobManager) dispatchHTTP(ctx context.Context, def JobDefinition, raw []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, def.DeliveryURI, bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to build delivery request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("delivery request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("delivery endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// dispatchTemporal starts a workflow for a "temporal://<task-queue>/<workflow-type>" URI.
+func (m *JobManager) dispatchTemporal(ctx context.Context, def JobDefinition, event map[string]interface{}) error {
+	if m.temporalClient == nil {
+		return fmt.Errorf("job %s: no Temporal client configured for temporal:// delivery", def.ID)
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(def.DeliveryURI, "temporal://"), "/", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("malformed temporal delivery URI %q, want temporal://<task-queue>/<workflow-type>", def.DeliveryURI)
+	}
+	taskQueue, workflowType := parts[0], parts[1]
+
+	_, err := m.temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{TaskQueue: taskQueue}, workflowType, event)
+	return err
+}
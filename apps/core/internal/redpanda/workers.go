@@ -0,0 +1,174 @@
+package redpanda
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"iterateswarm-core/internal/otel"
+)
+
+// RetryPolicy configures how ConsumeWorkers retries a failing handler
+// before giving up and routing the message to a dead-letter topic.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times handler is called for a
+	// message before it is dead-lettered; defaults to 3 if <= 0.
+	MaxAttempts int
+	// BaseDelay and MaxDelay bound the exponential backoff between
+	// attempts; default to 100ms and 5s if unset.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// DeadLetterTopic receives the raw value of a message that exhausted
+	// MaxAttempts, via Client.PublishToTopic. Dead-lettering is skipped if
+	// empty.
+	DeadLetterTopic string
+}
+
+// DefaultRetryPolicy calls the handler up to 3 times, with exponential
+// backoff capped at 5s between attempts, before forwarding the message to
+// "<topic>-dlq".
+func DefaultRetryPolicy(topic string) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:     3,
+		BaseDelay:       100 * time.Millisecond,
+		MaxDelay:        5 * time.Second,
+		DeadLetterTopic: topic + "-dlq",
+	}
+}
+
+func (p RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return 3
+	}
+	return p.MaxAttempts
+}
+
+// delay returns the backoff before retry attempt (1-indexed), with jitter,
+// following the same shape as grpc.backoffDelay.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 5 * time.Second
+	}
+
+	d := time.Duration(float64(base) * math.Pow(2, float64(attempt-1)))
+	if d > maxDelay {
+		d = maxDelay
+	}
+	return d + time.Duration(rand.Int63n(int64(d)/2+1))
+}
+
+// ConsumeWorkers runs n worker goroutines that fetch messages from topic
+// through a shared channel and hand each to handler. A message is only
+// committed to the consumer group once it has been durably handled: either
+// handler succeeded, or every attempt allowed by policy was exhausted and
+// the message was forwarded to policy.DeadLetterTopic. ConsumeWorkers
+// blocks until ctx is cancelled and every in-flight handler call has
+// returned, so callers can run it from a goroutine and rely on it to
+// unblock deterministically on shutdown.
+func (c *Client) ConsumeWorkers(ctx context.Context, topic string, n int, handler func(context.Context, kafka.Message) error) error {
+	if n <= 0 {
+		return fmt.Errorf("redpanda: ConsumeWorkers requires at least one worker")
+	}
+
+	policy := DefaultRetryPolicy(topic)
+
+	fetched := make(chan kafka.Message)
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(fetched)
+
+		for {
+			msg, err := c.reader.FetchMessage(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Printf("Consumer fetch error: %v", err)
+				continue
+			}
+
+			select {
+			case fetched <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range fetched {
+				c.processMessage(ctx, topic, msg, policy, handler)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return ctx.Err()
+}
+
+// processMessage runs handler with policy's retry/backoff, dead-letters the
+// message if every attempt fails, and commits the offset once the message
+// has been durably handled one way or the other.
+func (c *Client) processMessage(ctx context.Context, topic string, msg kafka.Message, policy RetryPolicy, handler func(context.Context, kafka.Message) error) {
+	msgCtx := otel.ExtractKafkaHeaders(ctx, msg.Headers)
+	msgCtx, span := tracer.Start(msgCtx, fmt.Sprintf("%s process", topic), oteltrace.WithSpanKind(oteltrace.SpanKindConsumer),
+		oteltrace.WithAttributes(
+			semconv.MessagingSystem("kafka"),
+			semconv.MessagingDestinationName(topic),
+			attribute.String("messaging.operation", "process"),
+		),
+	)
+	defer span.End()
+
+	var err error
+	for attempt := 1; attempt <= policy.maxAttempts(); attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				span.RecordError(ctx.Err())
+				return
+			case <-time.After(policy.delay(attempt - 1)):
+			}
+		}
+
+		err = handler(msgCtx, msg)
+		if err == nil {
+			break
+		}
+		log.Printf("Handler failed for %s (attempt %d/%d): %v", topic, attempt, policy.maxAttempts(), err)
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		if policy.DeadLetterTopic != "" {
+			if dlqErr := c.PublishToTopic(policy.DeadLetterTopic, msg.Value); dlqErr != nil {
+				log.Printf("Failed to publish to dead-letter topic %s: %v", policy.DeadLetterTopic, dlqErr)
+				return
+			}
+		}
+	}
+
+	if commitErr := c.reader.CommitMessages(ctx, msg); commitErr != nil {
+		log.Printf("Failed to commit message: %v", commitErr)
+	}
+}
@@ -0,0 +1,49 @@
+package retry
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HTTPError wraps a non-2xx HTTP response so ShouldRetry can make a
+// status-code-aware decision instead of treating every error alike.
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *HTTPError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("http %d: %v", e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("http %d: %s", e.StatusCode, e.Body)
+}
+
+// Unwrap exposes Err so errors.Is/errors.As can see through HTTPError to
+// whatever underlying error (if any) it carries.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// ParseRetryAfter parses an HTTP Retry-After header value, which per
+// RFC 9110 is either a number of seconds or an HTTP-date. It reports
+// false if value is empty or unparseable as either form.
+func ParseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(secs * float64(time.Second)), true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -2,21 +2,25 @@ package retry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
+	"net/http"
 	"time"
+
+	"iterateswarm-core/internal/ratelimit"
 )
 
 // RetryConfig configures retry behavior.
 type RetryConfig struct {
-	MaxRetries        int           // Maximum number of retries
-	InitialDelay      time.Duration // Initial delay between retries
-	MaxDelay          time.Duration // Maximum delay between retries
-	BackoffMultiplier float64       // Multiplier for exponential backoff
-	Jitter            time.Duration // Random jitter added to delay
-	RetryOnError      []error       // Specific errors to retry on
-	RetryOnStatusCodes []int        // HTTP status codes to retry on
+	MaxRetries         int           // Maximum number of retries
+	InitialDelay       time.Duration // Initial delay between retries
+	MaxDelay           time.Duration // Maximum delay between retries
+	BackoffMultiplier  float64       // Multiplier for exponential backoff
+	Jitter             time.Duration // Random jitter added to delay
+	RetryOnError       []error       // Specific errors to retry on
+	RetryOnStatusCodes []int         // HTTP status codes to retry on
 }
 
 // DefaultRetryConfig returns a sensible default configuration.
@@ -32,8 +36,46 @@ func DefaultRetryConfig() *RetryConfig {
 
 // ShouldRetry determines if an error should trigger a retry.
 func (c *RetryConfig) ShouldRetry(err error, statusCode int) bool {
-	// Retry on context cancellation
-	if err == context.Canceled || err == context.DeadlineExceeded {
+	// A caller that cancelled its own context doesn't want another attempt.
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	// An HTTPError carries the real status code regardless of what the
+	// caller passed as statusCode; RetryOnStatusCodes, when set, is the
+	// final word on which codes retry. With no explicit list, fall back
+	// to 5xx plus the handful of 4xx codes that are safe to retry
+	// (408 Request Timeout, 425 Too Early, 429 Too Many Requests) — every
+	// other 4xx (400, 401, 403, 404, ...) means retrying won't help.
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		if len(c.RetryOnStatusCodes) > 0 {
+			for _, code := range c.RetryOnStatusCodes {
+				if code == httpErr.StatusCode {
+					return true
+				}
+			}
+			return false
+		}
+		if httpErr.StatusCode >= 500 {
+			return true
+		}
+		switch httpErr.StatusCode {
+		case 408, 425, 429:
+			return true
+		default:
+			return false
+		}
+	}
+
+	// Rate-limited requests always retry; Retry honors RetryAfter instead
+	// of its usual exponential backoff for these.
+	var rlErr *ratelimit.Error
+	if errors.As(err, &rlErr) {
+		return true
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
 		return true
 	}
 
@@ -74,13 +116,12 @@ func Retry(ctx context.Context, config *RetryConfig, fn RetryFunc) (interface{},
 
 	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
 		if attempt > 0 {
-			// Calculate delay with exponential backoff and jitter
-			delay := time.Duration(float64(config.InitialDelay) *
-				math.Pow(config.BackoffMultiplier, float64(attempt-1)))
-			delay = min(delay, config.MaxDelay)
-			// Add jitter
-			jitter := time.Duration(rand.Int63n(int64(config.Jitter) + 1))
-			delay = delay + jitter
+			delay := retryDelay(config, attempt, lastErr)
+			if deadline, ok := ctx.Deadline(); ok {
+				if remaining := time.Until(deadline); remaining < delay {
+					delay = remaining
+				}
+			}
 
 			select {
 			case <-ctx.Done():
@@ -111,35 +152,49 @@ func Retry(ctx context.Context, config *RetryConfig, fn RetryFunc) (interface{},
 	return result, fmt.Errorf("retry failed after %d attempts: %w", config.MaxRetries+1, lastErr)
 }
 
+// retryDelay picks the wait before the next attempt. An *HTTPError's or
+// *ratelimit.Error's RetryAfter carries the server's own required wait,
+// which takes priority over the usual exponential backoff; Retry's loop
+// additionally caps whichever delay this returns by ctx's deadline.
+func retryDelay(config *RetryConfig, attempt int, lastErr error) time.Duration {
+	var httpErr *HTTPError
+	if errors.As(lastErr, &httpErr) && httpErr.RetryAfter > 0 {
+		return httpErr.RetryAfter
+	}
+
+	var rlErr *ratelimit.Error
+	if errors.As(lastErr, &rlErr) {
+		return rlErr.RetryAfter
+	}
+
+	delay := time.Duration(float64(config.InitialDelay) *
+		math.Pow(config.BackoffMultiplier, float64(attempt-1)))
+	delay = min(delay, config.MaxDelay)
+	jitter := time.Duration(rand.Int63n(int64(config.Jitter) + 1))
+	return delay + jitter
+}
+
 // extractStatusCode attempts to extract HTTP status code from error.
 func extractStatusCode(err error) int {
 	if err == nil {
 		return 0
 	}
-	// Try to extract from error message
-	// This is a simple heuristic - in production you'd want better error typing
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+	var rlErr *ratelimit.Error
+	if errors.As(err, &rlErr) {
+		return http.StatusTooManyRequests
+	}
 	return 0
 }
 
-// SimpleRetry is a convenience function for simple retry scenarios.
+// SimpleRetry is a convenience function for simple retry scenarios, using
+// DefaultRetryConfig's backoff under the hood.
 func SimpleRetry(fn func() error) error {
-	config := DefaultRetryConfig()
-
-	var lastErr error
-	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
-		if attempt > 0 {
-			delay := time.Duration(float64(config.InitialDelay) *
-				math.Pow(config.BackoffMultiplier, float64(attempt-1)))
-			delay = min(delay, config.MaxDelay)
-			jitter := time.Duration(rand.Int63n(int64(config.Jitter) + 1))
-			time.Sleep(delay + jitter)
-		}
-
-		lastErr = fn()
-		if lastErr == nil {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("retry failed after %d attempts: %w", config.MaxRetries+1, lastErr)
+	_, err := Retry(context.Background(), DefaultRetryConfig(), func(attempt int) (interface{}, error) {
+		return nil, fn()
+	})
+	return err
 }
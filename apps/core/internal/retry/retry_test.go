@@ -0,0 +1,185 @@
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"iterateswarm-core/internal/ratelimit"
+)
+
+func TestShouldRetryHTTPErrorStatusCodes(t *testing.T) {
+	config := DefaultRetryConfig()
+
+	tests := []struct {
+		statusCode int
+		want       bool
+	}{
+		{500, true},
+		{503, true},
+		{408, true},
+		{425, true},
+		{429, true},
+		{400, false},
+		{401, false},
+		{403, false},
+		{404, false},
+	}
+
+	for _, tt := range tests {
+		err := &HTTPError{StatusCode: tt.statusCode}
+		if got := config.ShouldRetry(err, 0); got != tt.want {
+			t.Errorf("ShouldRetry(HTTPError{%d}) = %v, want %v", tt.statusCode, got, tt.want)
+		}
+	}
+}
+
+func TestShouldRetryHonorsExplicitStatusCodeList(t *testing.T) {
+	config := DefaultRetryConfig()
+	config.RetryOnStatusCodes = []int{503}
+
+	if !config.ShouldRetry(&HTTPError{StatusCode: 503}, 0) {
+		t.Error("expected 503 to retry, it's in RetryOnStatusCodes")
+	}
+	if config.ShouldRetry(&HTTPError{StatusCode: 500}, 0) {
+		t.Error("expected 500 not to retry, only 503 is in RetryOnStatusCodes")
+	}
+}
+
+func TestShouldRetryRateLimitErrorAlwaysRetries(t *testing.T) {
+	config := DefaultRetryConfig()
+	err := &ratelimit.Error{RetryAfter: time.Second, Global: true}
+	if !config.ShouldRetry(err, 0) {
+		t.Error("expected a ratelimit.Error to always retry")
+	}
+}
+
+func TestShouldRetryContextCanceledNeverRetries(t *testing.T) {
+	config := DefaultRetryConfig()
+	if config.ShouldRetry(context.Canceled, 0) {
+		t.Error("expected context.Canceled not to retry")
+	}
+}
+
+func TestRetryDelayUsesRetryAfterFromHTTPError(t *testing.T) {
+	config := DefaultRetryConfig()
+	err := &HTTPError{StatusCode: 429, RetryAfter: 2 * time.Second}
+	if got := retryDelay(config, 1, err); got != 2*time.Second {
+		t.Fatalf("retryDelay() = %s, want 2s", got)
+	}
+}
+
+func TestRetryDelayUsesRetryAfterFromRateLimitError(t *testing.T) {
+	config := DefaultRetryConfig()
+	err := &ratelimit.Error{RetryAfter: 3 * time.Second}
+	if got := retryDelay(config, 1, err); got != 3*time.Second {
+		t.Fatalf("retryDelay() = %s, want 3s", got)
+	}
+}
+
+func TestRetrySucceedsAfterTransientFailures(t *testing.T) {
+	config := &RetryConfig{
+		MaxRetries:        3,
+		InitialDelay:      time.Millisecond,
+		MaxDelay:          10 * time.Millisecond,
+		BackoffMultiplier: 2.0,
+	}
+
+	attempts := 0
+	result, err := Retry(context.Background(), config, func(attempt int) (interface{}, error) {
+		attempts++
+		if attempt < 2 {
+			return nil, &HTTPError{StatusCode: 503}
+		}
+		return "ok", nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "ok" {
+		t.Fatalf("result = %v, want ok", result)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRetryGivesUpOnNonRetryableError(t *testing.T) {
+	config := DefaultRetryConfig()
+	attempts := 0
+	_, err := Retry(context.Background(), config, func(attempt int) (interface{}, error) {
+		attempts++
+		return nil, &HTTPError{StatusCode: 404}
+	})
+	if err == nil {
+		t.Fatal("expected an error for a non-retryable status code")
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1 (should not retry a 404)", attempts)
+	}
+}
+
+func TestRetryStopsOnContextCancellation(t *testing.T) {
+	config := &RetryConfig{
+		MaxRetries:        5,
+		InitialDelay:      time.Second,
+		MaxDelay:          time.Second,
+		BackoffMultiplier: 2.0,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := Retry(ctx, config, func(attempt int) (interface{}, error) {
+			return nil, &HTTPError{StatusCode: 500}
+		})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an error once ctx's deadline was exceeded")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Retry did not return after ctx's deadline passed; it ignored cancellation")
+	}
+}
+
+func TestParseRetryAfterSeconds(t *testing.T) {
+	d, ok := ParseRetryAfter("5")
+	if !ok || d != 5*time.Second {
+		t.Fatalf("ParseRetryAfter(\"5\") = %s, %v, want 5s, true", d, ok)
+	}
+}
+
+func TestParseRetryAfterEmpty(t *testing.T) {
+	if _, ok := ParseRetryAfter(""); ok {
+		t.Fatal("expected ok=false for empty Retry-After value")
+	}
+}
+
+func TestParseRetryAfterUnparseable(t *testing.T) {
+	if _, ok := ParseRetryAfter("not-a-date-or-number"); ok {
+		t.Fatal("expected ok=false for unparseable Retry-After value")
+	}
+}
+
+func TestSimpleRetrySucceeds(t *testing.T) {
+	calls := 0
+	err := SimpleRetry(func() error {
+		calls++
+		if calls < 2 {
+			return &HTTPError{StatusCode: 500}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
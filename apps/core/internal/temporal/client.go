@@ -5,36 +5,49 @@ import (
 	"log"
 
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
 )
 
 // Client wraps the Temporal client.
 type Client struct {
-	Client client.Client
+	Client    client.Client
+	Namespace string
 }
 
-// NewClient creates a new Temporal client.
+// NewClient creates a new Temporal client with no client-side interceptors.
 func NewClient(hostPort, namespace string) (*Client, error) {
+	return NewClientWithInterceptors(hostPort, namespace, nil)
+}
+
+// NewClientWithInterceptors creates a Temporal client with interceptors
+// installed, e.g. otel.NewTemporalInterceptor() so ExecuteWorkflow/
+// SignalWorkflow calls start a span continuing the trace active in the
+// caller's context.
+func NewClientWithInterceptors(hostPort, namespace string, interceptors []interceptor.ClientInterceptor) (*Client, error) {
 	log.Printf("Connecting to Temporal at %s", hostPort)
 
 	c, err := client.Dial(client.Options{
-		HostPort:  hostPort,
-		Namespace: namespace,
+		HostPort:     hostPort,
+		Namespace:    namespace,
+		Interceptors: interceptors,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{Client: c}, nil
+	return &Client{Client: c, Namespace: namespace}, nil
 }
 
-// StartWorkflow starts a new workflow execution.
-func (c *Client) StartWorkflow(ctx context.Context, workflowID, taskQueue string, input interface{}) (client.WorkflowRun, error) {
+// StartWorkflow starts a new execution of workflowType (its registered
+// name, e.g. "FeedbackWorkflow") with input as its sole argument.
+func (c *Client) StartWorkflow(ctx context.Context, workflowID, taskQueue, workflowType string, input interface{}) (client.WorkflowRun, error) {
 	run, err := c.Client.ExecuteWorkflow(
 		ctx,
 		client.StartWorkflowOptions{
 			ID:        workflowID,
 			TaskQueue: taskQueue,
 		},
+		workflowType,
 		input,
 	)
 	if err != nil {
@@ -7,22 +7,31 @@ import (
 	"strings"
 	"time"
 
+	"iterateswarm-core/internal/bridge"
 	"iterateswarm-core/internal/grpc"
+	"iterateswarm-core/internal/integrations"
 	"iterateswarm-core/internal/logging"
 	"iterateswarm-core/internal/retry"
 
+	pb "github.com/Aparnap2/iterate_swarm/gen/go/ai/v1"
 	"github.com/bwmarrin/discordgo"
 	"github.com/google/go-github/v50/github"
+	"github.com/google/uuid"
 	"golang.org/x/oauth2"
 )
 
 // Activities contains the workflow activities.
 type Activities struct {
 	aiClient *grpc.Client
+	batcher  *grpc.StreamBatcher
 	logger   *logging.Logger
+	bridges  *bridge.Registry
+	notifier *integrations.MultiSender
 }
 
-// NewActivities creates a new Activities instance.
+// NewActivities creates a new Activities instance. CreateGitHubIssue uses
+// the GITHUB_TOKEN/GITHUB_OWNER/GITHUB_REPO environment variables directly,
+// with no bridge routing, and AnalyzeFeedback issues one unary RPC per call.
 func NewActivities(aiClient *grpc.Client) *Activities {
 	return &Activities{
 		aiClient: aiClient,
@@ -30,6 +39,64 @@ func NewActivities(aiClient *grpc.Client) *Activities {
 	}
 }
 
+// NewActivitiesWithBridges creates an Activities instance whose
+// CreateGitHubIssue activity fans approved feedback out to whichever
+// tracker registry.Route selects for the issue's labels, instead of always
+// filing against the repo hardcoded in GITHUB_OWNER/GITHUB_REPO.
+func NewActivitiesWithBridges(aiClient *grpc.Client, registry *bridge.Registry) *Activities {
+	return &Activities{
+		aiClient: aiClient,
+		logger:   logging.NewLogger("workflow"),
+		bridges:  registry,
+	}
+}
+
+// SetBridges attaches the tracker registry CreateGitHubIssue routes
+// through, letting a caller wire it in alongside other options (e.g.
+// NewActivitiesWithBatcher's RPC batching) instead of picking one
+// constructor overload. Nil is a valid value: CreateGitHubIssue then falls
+// back to its GITHUB_TOKEN/GITHUB_OWNER/GITHUB_REPO path.
+func (a *Activities) SetBridges(registry *bridge.Registry) {
+	a.bridges = registry
+}
+
+// SetNotifier attaches the fan-out sender CreateGitHubIssue notifies on a
+// successful filing. Nil is a valid value: CreateGitHubIssue then simply
+// doesn't notify anywhere beyond the tracker itself.
+func (a *Activities) SetNotifier(notifier *integrations.MultiSender) {
+	a.notifier = notifier
+}
+
+// notifyIssueCreated best-effort fans out issueURL to every NOTIFY_URLS
+// destination. The message is a plain string since NOTIFY_URLS targets
+// are built via integrations.NewFromURL and can be any of
+// DiscordAdapter/SlackAdapter/TeamsAdapter/GenericAdapter — a string is
+// the one payload shape every one of those Send implementations accepts
+// (see their doc comments), unlike a raw map, which DiscordAdapter/
+// SlackAdapter reject outright. A notification failure is logged but
+// never fails the activity: the issue itself was already filed successfully.
+func (a *Activities) notifyIssueCreated(ctx context.Context, issueURL string, input CreateGitHubIssueInput) {
+	if a.notifier == nil {
+		return
+	}
+	message := fmt.Sprintf("New issue created: %s\n%s\nLabels: %s", input.Title, issueURL, strings.Join(input.Labels, ", "))
+	if err := a.notifier.Send(ctx, message); err != nil {
+		a.logger.Warn("failed to notify one or more NOTIFY_URLS destinations", "issue_url", issueURL, "error", err.Error())
+	}
+}
+
+// NewActivitiesWithBatcher creates an Activities instance whose
+// AnalyzeFeedback activity submits through batcher instead of issuing a
+// unary AnalyzeFeedback RPC directly, coalescing concurrent activity
+// executions into shared AnalyzeFeedbackStream frames.
+func NewActivitiesWithBatcher(aiClient *grpc.Client, batcher *grpc.StreamBatcher) *Activities {
+	return &Activities{
+		aiClient: aiClient,
+		batcher:  batcher,
+		logger:   logging.NewLogger("workflow"),
+	}
+}
+
 // AnalyzeFeedbackInput is the input for the AnalyzeFeedback activity.
 type AnalyzeFeedbackInput struct {
 	Text      string
@@ -59,13 +126,32 @@ func (a *Activities) AnalyzeFeedback(ctx context.Context, input AnalyzeFeedbackI
 		"text_length", len(input.Text),
 	)
 
-	resp, err := a.aiClient.AnalyzeFeedback(ctx, input.Text, input.Source, input.UserID)
-	if err != nil {
-		a.logger.Error("analyze feedback failed", err,
-			"source", input.Source,
-			"user_id", input.UserID,
-		)
-		return nil, err
+	var resp *pb.AnalyzeFeedbackResponse
+	if a.batcher != nil {
+		streamResp, err := a.batcher.Submit(ctx, grpc.StreamRequest{
+			RequestID: uuid.New().String(),
+			Text:      input.Text,
+			Source:    input.Source,
+			UserID:    input.UserID,
+		})
+		if err != nil {
+			a.logger.Error("analyze feedback failed", err,
+				"source", input.Source,
+				"user_id", input.UserID,
+			)
+			return nil, err
+		}
+		resp = streamResp.AnalyzeFeedbackResponse
+	} else {
+		var err error
+		resp, err = a.aiClient.AnalyzeFeedback(ctx, input.Text, input.Source, input.UserID)
+		if err != nil {
+			a.logger.Error("analyze feedback failed", err,
+				"source", input.Source,
+				"user_id", input.UserID,
+			)
+			return nil, err
+		}
 	}
 
 	output := &AnalyzeFeedbackOutput{
@@ -241,6 +327,95 @@ func (a *Activities) SendDiscordApproval(ctx context.Context, input SendDiscordA
 	return nil
 }
 
+// SendSlackApprovalInput is the input for the SendSlackApproval activity.
+type SendSlackApprovalInput struct {
+	ChannelID     string
+	IssueTitle    string
+	IssueBody     string
+	IssueLabels   []string
+	Severity      string
+	IssueType     string
+	WorkflowRunID string
+}
+
+// SendSlackApproval sends an approval request to Slack as a Block Kit
+// message with Approve/Reject buttons, whose action_id (approve:<id> /
+// reject:<id>) HandleSlackInteraction's router dispatches back through
+// signalWorkflowAction the same way Discord's custom_id does.
+func (a *Activities) SendSlackApproval(ctx context.Context, input SendSlackApprovalInput) error {
+	startTime := time.Now()
+	a.logger.Info("sending slack approval request",
+		"channel_id", input.ChannelID,
+		"issue_title", input.IssueTitle,
+		"workflow_run_id", input.WorkflowRunID,
+	)
+
+	webhookURL := os.Getenv("SLACK_WEBHOOK_URL")
+	if webhookURL == "" {
+		a.logger.Warn("slack webhook url not configured, skipping notification")
+		return nil
+	}
+
+	emoji := issueTypeEmoji[strings.ToLower(input.IssueType)]
+	if emoji == "" {
+		emoji = issueTypeEmoji["unspecified"]
+	}
+
+	adapter := integrations.NewSlackAdapter(webhookURL, 15*time.Second)
+
+	msg := integrations.SlackMessage{
+		Channel: input.ChannelID,
+		Text:    fmt.Sprintf("%s New issue proposed: %s", emoji, input.IssueTitle),
+		Blocks: []integrations.SlackBlock{
+			{
+				Type: integrations.SlackBlockSection,
+				Text: &integrations.SlackText{Type: "mrkdwn", Text: fmt.Sprintf("%s *New Issue Proposed: %s*\n%s", emoji, input.IssueTitle, truncateString(input.IssueBody, 3000))},
+			},
+			{
+				Type: integrations.SlackBlockContext,
+				Elements: []integrations.SlackElement{
+					{Type: "mrkdwn", Text: &integrations.SlackText{Type: "mrkdwn", Text: fmt.Sprintf("Severity: *%s*  |  Type: *%s*  |  Labels: %s  |  Workflow ID: `%s`",
+						strings.ToUpper(input.Severity), strings.ToUpper(input.IssueType), strings.Join(input.IssueLabels, ", "), input.WorkflowRunID)}},
+				},
+			},
+			{
+				Type: integrations.SlackBlockActions,
+				Elements: []integrations.SlackElement{
+					{
+						Type:     integrations.SlackElementButton,
+						ActionID: fmt.Sprintf("approve:%s", input.WorkflowRunID),
+						Text:     &integrations.SlackText{Type: "plain_text", Text: "Approve"},
+						Style:    "primary",
+						Value:    input.WorkflowRunID,
+					},
+					{
+						Type:     integrations.SlackElementButton,
+						ActionID: fmt.Sprintf("reject:%s", input.WorkflowRunID),
+						Text:     &integrations.SlackText{Type: "plain_text", Text: "Reject"},
+						Style:    "danger",
+						Value:    input.WorkflowRunID,
+					},
+				},
+			},
+		},
+	}
+
+	err := retry.SimpleRetry(func() error {
+		return adapter.Send(ctx, msg)
+	})
+	if err != nil {
+		a.logger.Error("failed to send slack message", err, "channel_id", input.ChannelID)
+		return fmt.Errorf("failed to send Slack message: %w", err)
+	}
+
+	duration := time.Since(startTime)
+	a.logger.LogActivity(ctx, "SendSlackApproval", duration, true,
+		"channel_id", input.ChannelID,
+	)
+
+	return nil
+}
+
 // CreateGitHubIssueInput is the input for the CreateGitHubIssue activity.
 type CreateGitHubIssueInput struct {
 	Title     string
@@ -260,6 +435,10 @@ func (a *Activities) CreateGitHubIssue(ctx context.Context, input CreateGitHubIs
 		"repo_name", input.RepoName,
 	)
 
+	if a.bridges != nil {
+		return a.createIssueViaBridge(ctx, startTime, input)
+	}
+
 	// Get GitHub token from environment
 	githubToken := os.Getenv("GITHUB_TOKEN")
 	if githubToken == "" {
@@ -331,10 +510,40 @@ func (a *Activities) CreateGitHubIssue(ctx context.Context, input CreateGitHubIs
 		"issue_url", issueURL,
 		"issue_number", issue.GetNumber(),
 	)
+	a.notifyIssueCreated(ctx, issueURL, input)
 
 	return issueURL, nil
 }
 
+// createIssueViaBridge files input through the configured bridge registry,
+// routing by label/routing rules instead of always targeting GitHub.
+func (a *Activities) createIssueViaBridge(ctx context.Context, startTime time.Time, input CreateGitHubIssueInput) (string, error) {
+	ref, err := a.bridges.CreateIssue(ctx, bridge.IssueSpec{
+		Title:     input.Title,
+		Body:      input.Body,
+		Labels:    input.Labels,
+		Assignee:  input.Assignee,
+		RepoOwner: input.RepoOwner,
+		RepoName:  input.RepoName,
+	})
+	if err != nil {
+		a.logger.Error("failed to create issue via bridge", err,
+			"repo_owner", input.RepoOwner,
+			"repo_name", input.RepoName,
+		)
+		return "", fmt.Errorf("failed to create issue via bridge: %w", err)
+	}
+
+	duration := time.Since(startTime)
+	a.logger.LogActivity(ctx, "CreateGitHubIssue", duration, true,
+		"issue_url", ref.URL,
+		"tracker", ref.Tracker,
+	)
+	a.notifyIssueCreated(ctx, ref.URL, input)
+
+	return ref.URL, nil
+}
+
 // truncateString truncates a string to the specified max length.
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -1,9 +1,12 @@
 package workflow
 
 import (
+	"strings"
 	"time"
 
 	"go.temporal.io/sdk/workflow"
+
+	"iterateswarm-core/internal/discord"
 )
 
 // FeedbackInput is the input to the FeedbackWorkflow.
@@ -25,8 +28,9 @@ func FeedbackWorkflow(ctx workflow.Context, input FeedbackInput) error {
 	}
 	ctx = workflow.WithActivityOptions(ctx, ao)
 
-	// Channel for receiving signals (user approval)
-	signalChan := workflow.GetSignalChannel(ctx, "user-action")
+	// Channel for receiving the reviewer's approve/reject decision, sent by
+	// discord.Gateway once it correlates a button click back to this workflow.
+	signalChan := workflow.GetSignalChannel(ctx, discord.ApprovalSignalName)
 
 	var analyzeResult *AnalyzeFeedbackOutput
 
@@ -46,29 +50,44 @@ func FeedbackWorkflow(ctx workflow.Context, input FeedbackInput) error {
 		return nil
 	}
 
-	// Step 2: Send approval request to Discord
-	err = workflow.ExecuteActivity(ctx, "SendDiscordApproval", SendDiscordApprovalInput{
-		ChannelID:     input.ChannelID,
-		IssueTitle:    analyzeResult.Title,
-		IssueBody:     analyzeResult.Description,
-		IssueLabels:   analyzeResult.Labels,
-		Severity:      analyzeResult.Severity,
-		IssueType:     analyzeResult.IssueType,
-		WorkflowRunID: "workflow-" + input.UserID + "-" + input.Source,
-	}).Get(ctx, nil)
+	// Step 2: Send the approval request to whichever platform the feedback
+	// came from. WorkflowRunID must be this workflow's actual Temporal
+	// WorkflowID (not a freshly derived string) since it's embedded
+	// verbatim in the Approve/Reject button's custom_id/action_id, and
+	// discord.Gateway.signalAndAck (Discord) / api.Handler.
+	// signalWorkflowAction (Slack) signal that exact ID back.
+	workflowRunID := workflow.GetInfo(ctx).WorkflowExecution.ID
+	if strings.EqualFold(input.Source, "slack") {
+		err = workflow.ExecuteActivity(ctx, "SendSlackApproval", SendSlackApprovalInput{
+			ChannelID:     input.ChannelID,
+			IssueTitle:    analyzeResult.Title,
+			IssueBody:     analyzeResult.Description,
+			IssueLabels:   analyzeResult.Labels,
+			Severity:      analyzeResult.Severity,
+			IssueType:     analyzeResult.IssueType,
+			WorkflowRunID: workflowRunID,
+		}).Get(ctx, nil)
+	} else {
+		err = workflow.ExecuteActivity(ctx, "SendDiscordApproval", SendDiscordApprovalInput{
+			ChannelID:     input.ChannelID,
+			IssueTitle:    analyzeResult.Title,
+			IssueBody:     analyzeResult.Description,
+			IssueLabels:   analyzeResult.Labels,
+			Severity:      analyzeResult.Severity,
+			IssueType:     analyzeResult.IssueType,
+			WorkflowRunID: workflowRunID,
+		}).Get(ctx, nil)
+	}
 	if err != nil {
 		return err
 	}
 
-	// Step 3: Wait for user approval (signal with timeout)
-	// Use workflow.AwaitWithTimeout for signal + timeout
-	var signalValue interface{}
+	// Step 3: Wait for the reviewer's approval signal, with a 5 minute timeout.
+	var signal discord.ApprovalSignal
 	signalReceived := false
 
-	// Wait for signal with 5 minute timeout
 	_, _ = workflow.AwaitWithTimeout(ctx, 5*time.Minute, func() bool {
-		// Check if we have a signal
-		received := signalChan.ReceiveAsync(&signalValue)
+		received := signalChan.ReceiveAsync(&signal)
 		if received {
 			signalReceived = true
 			return true
@@ -76,16 +95,8 @@ func FeedbackWorkflow(ctx workflow.Context, input FeedbackInput) error {
 		return false
 	})
 
-	// Check if we received a signal or timed out
-	approved := false
-	if signalReceived {
-		if s, ok := signalValue.(string); ok {
-			approved = s == "approve"
-		}
-	}
-
 	// Step 4: Handle approval/rejection
-	if !approved {
+	if !signalReceived || !signal.Approved {
 		return nil
 	}
 
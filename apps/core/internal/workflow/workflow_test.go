@@ -0,0 +1,76 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/testsuite"
+
+	"iterateswarm-core/internal/discord"
+)
+
+// TestFeedbackWorkflowApprovalRoundTrip proves that the WorkflowRunID
+// SendDiscordApproval hands to Discord is this workflow's real Temporal
+// WorkflowID, by starting a workflow under a known ID, capturing the ID
+// SendDiscordApproval was actually called with, and signaling that same
+// ID the way discord.Gateway.signalAndAck would — a mismatch here means
+// every real Approve/Reject click signals a workflow ID that was never
+// started.
+func TestFeedbackWorkflowApprovalRoundTrip(t *testing.T) {
+	const wantWorkflowID = "feedback-workflow-test-id"
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.SetStartWorkflowOptions(client.StartWorkflowOptions{ID: wantWorkflowID})
+
+	env.OnActivity("AnalyzeFeedback", mock.Anything, mock.Anything).Return(&AnalyzeFeedbackOutput{
+		IsDuplicate: false,
+		Title:       "Button is unresponsive",
+		Description: "Clicking submit does nothing",
+		Severity:    "high",
+		IssueType:   "bug",
+		Labels:      []string{"bug"},
+	}, nil)
+
+	var gotWorkflowRunID string
+	env.OnActivity("SendDiscordApproval", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		input := args.Get(1).(SendDiscordApprovalInput)
+		gotWorkflowRunID = input.WorkflowRunID
+	}).Return(nil)
+
+	env.OnActivity("CreateGitHubIssue", mock.Anything, mock.Anything).Return("https://github.com/acme/widget/issues/42", nil)
+
+	// Simulate the reviewer clicking Approve: discord.Gateway.signalAndAck
+	// would parse wantWorkflowID back out of the button's custom_id and
+	// call SignalWorkflow(ctx, wantWorkflowID, ...) with this same payload.
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(discord.ApprovalSignalName, discord.ApprovalSignal{
+			Approved: true,
+			UserID:   "reviewer-1",
+		})
+	}, time.Millisecond)
+
+	env.ExecuteWorkflow(FeedbackWorkflow, FeedbackInput{
+		Text:      "the submit button does nothing",
+		Source:    "discord",
+		UserID:    "reporter-1",
+		ChannelID: "chan-1",
+	})
+
+	if !env.IsWorkflowCompleted() {
+		t.Fatal("expected workflow to complete")
+	}
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatalf("workflow returned error: %v", err)
+	}
+
+	if gotWorkflowRunID != wantWorkflowID {
+		t.Fatalf("SendDiscordApproval got WorkflowRunID %q, want %q (the workflow's real WorkflowID) — "+
+			"a mismatch means Approve/Reject clicks would signal a workflow ID that was never started",
+			gotWorkflowRunID, wantWorkflowID)
+	}
+
+	env.AssertExpectations(t)
+}